@@ -0,0 +1,77 @@
+package topsis
+
+import (
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// BestWorst menghitung bobot dengan model linear Best-Worst Method:
+//
+//	minimize ξ
+//	|w_B - a_Bj·w_j| <= ξ dan |w_j - a_jW·w_W| <= ξ untuk setiap j
+//	Σ w_j = 1, w_j >= 0
+//
+// Rasio konsistensi adalah ξ dibagi indeks konsistensi untuk a_BW; makin
+// dekat ke 0 makin konsisten.
+func BestWorst(req helperTopsis.BWMRequest) (helperTopsis.BWMResult, error) {
+	if err := helperTopsis.ValidateBWMRequest(req); err != nil {
+		return helperTopsis.BWMResult{}, err
+	}
+	n := len(req.Criteria)
+	xi := n
+	index := make(map[string]int, n)
+	for i, criterion := range req.Criteria {
+		index[criterion.Name] = i
+	}
+	best, worst := index[req.Best], index[req.Worst]
+
+	lp := helperTopsis.LinearProgram{Objective: make([]float64, n+1)}
+	lp.Objective[xi] = 1
+	// selisih a - b·c dibatasi dua arah oleh ξ
+	addAbsolute := func(a, c int, b float64) {
+		for _, sign := range []float64{1, -1} {
+			row := make([]float64, n+1)
+			row[a] += sign
+			row[c] -= sign * b
+			row[xi] = -1
+			lp.Constraints = append(lp.Constraints, row)
+			lp.Bounds = append(lp.Bounds, 0)
+			lp.Equal = append(lp.Equal, false)
+		}
+	}
+	for j, criterion := range req.Criteria {
+		if j != best {
+			addAbsolute(best, j, req.BestToOthers[criterion.Name])
+		}
+		if j != worst && j != best {
+			addAbsolute(j, worst, req.OthersToWorst[criterion.Name])
+		}
+	}
+	sum := make([]float64, n+1)
+	for j := 0; j < n; j++ {
+		sum[j] = 1
+	}
+	lp.Constraints = append(lp.Constraints, sum)
+	lp.Bounds = append(lp.Bounds, 1)
+	lp.Equal = append(lp.Equal, true)
+
+	solution, optimum, err := helperTopsis.SolveLinearProgram(lp)
+	if err != nil {
+		return helperTopsis.BWMResult{}, err
+	}
+
+	result := helperTopsis.BWMResult{
+		Weights:          make(map[string]float64, n),
+		Xi:               optimum,
+		ConsistencyIndex: helperTopsis.BWMConsistencyIndex(req.BestToOthers[req.Worst]),
+		WeightedCriteria: make([]helperTopsis.Criterion, n),
+	}
+	if result.ConsistencyIndex > 0 {
+		result.ConsistencyRatio = optimum / result.ConsistencyIndex
+	}
+	copy(result.WeightedCriteria, req.Criteria)
+	for j, criterion := range req.Criteria {
+		result.Weights[criterion.Name] = solution[j]
+		result.WeightedCriteria[j].Weight = solution[j]
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package topsis
+
+import (
+	"fmt"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// GenerateAndRank membangkitkan alternatif dari kombinasi opsi lalu
+// meranking semuanya dengan pipeline Topsis biasa.
+func GenerateAndRank(req helperTopsis.GenerationRequest) (helperTopsis.GenerationResponse, error) {
+	if err := helperTopsis.ValidateGenerationRequest(req); err != nil {
+		return helperTopsis.GenerationResponse{}, err
+	}
+	alternatives, choices, pruned, err := helperTopsis.GenerateAlternatives(req)
+	if err != nil {
+		return helperTopsis.GenerationResponse{}, err
+	}
+	if len(alternatives) == 0 {
+		return helperTopsis.GenerationResponse{}, fmt.Errorf("all %d combinations were pruned", pruned)
+	}
+	req.TOPSISRequest.Alternatives = alternatives
+	response, err := Topsis(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.GenerationResponse{}, err
+	}
+	return helperTopsis.GenerationResponse{
+		TOPSISResponse: response,
+		Generated:      len(alternatives),
+		Pruned:         pruned,
+		Choices:        choices,
+	}, nil
+}
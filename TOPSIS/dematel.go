@@ -0,0 +1,127 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// Dematel menghitung matriks hubungan total T = N(I - N)⁻¹, dengan N matriks
+// pengaruh langsung dibagi jumlah baris atau kolom terbesar. D adalah jumlah
+// baris T (pengaruh yang diberikan) dan R jumlah kolom (pengaruh yang
+// diterima). Bobot kriteria sebanding dengan sqrt((D+R)² + (D-R)²).
+func Dematel(req helperTopsis.DEMATELRequest) (helperTopsis.DEMATELResult, error) {
+	if err := helperTopsis.ValidateDEMATELRequest(req); err != nil {
+		return helperTopsis.DEMATELResult{}, err
+	}
+	n := len(req.Criteria)
+	direct := make([][]float64, n)
+	for i, from := range req.Criteria {
+		direct[i] = make([]float64, n)
+		for j, to := range req.Criteria {
+			direct[i][j] = req.Influence[from.Name][to.Name]
+		}
+	}
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		rowSum, columnSum := 0.0, 0.0
+		for j := 0; j < n; j++ {
+			rowSum += direct[i][j]
+			columnSum += direct[j][i]
+		}
+		scale = math.Max(scale, math.Max(rowSum, columnSum))
+	}
+	if scale == 0 {
+		return helperTopsis.DEMATELResult{}, fmt.Errorf("influence matrix is empty")
+	}
+
+	// I - N
+	complement := make([][]float64, n)
+	for i := range direct {
+		complement[i] = make([]float64, n)
+		for j := range direct[i] {
+			direct[i][j] /= scale
+			complement[i][j] = -direct[i][j]
+		}
+		complement[i][i] += 1
+	}
+	inverse, ok := helperTopsis.InvertMatrix(complement)
+	if !ok {
+		return helperTopsis.DEMATELResult{}, fmt.Errorf("I - N is singular, the influence matrix does not converge")
+	}
+	total := make([][]float64, n)
+	for i := range total {
+		total[i] = make([]float64, n)
+		for j := range total[i] {
+			for k := 0; k < n; k++ {
+				total[i][j] += direct[i][k] * inverse[k][j]
+			}
+		}
+	}
+
+	result := helperTopsis.DEMATELResult{
+		TotalRelation: make(map[string]map[string]float64, n),
+		Criteria:      make([]helperTopsis.CriterionRelation, 0, n),
+	}
+	sum := 0.0
+	for i, from := range req.Criteria {
+		result.TotalRelation[from.Name] = make(map[string]float64, n)
+		for j, to := range req.Criteria {
+			result.TotalRelation[from.Name][to.Name] = total[i][j]
+			sum += total[i][j]
+		}
+	}
+	result.Threshold = sum / float64(n*n)
+	if req.Threshold != nil {
+		result.Threshold = *req.Threshold
+	}
+
+	weights := make([]float64, n)
+	weightSum := 0.0
+	for i, criterion := range req.Criteria {
+		dispatch, receive := 0.0, 0.0
+		for j := 0; j < n; j++ {
+			dispatch += total[i][j]
+			receive += total[j][i]
+		}
+		relation := helperTopsis.CriterionRelation{
+			Name:       criterion.Name,
+			Dispatch:   dispatch,
+			Receive:    receive,
+			Prominence: dispatch + receive,
+			Relation:   dispatch - receive,
+			Group:      helperTopsis.DEMATELEffect,
+		}
+		if relation.Relation > 0 {
+			relation.Group = helperTopsis.DEMATELCause
+			result.Cause = append(result.Cause, criterion.Name)
+		} else {
+			result.Effect = append(result.Effect, criterion.Name)
+		}
+		result.Criteria = append(result.Criteria, relation)
+		weights[i] = math.Hypot(relation.Prominence, relation.Relation)
+		weightSum += weights[i]
+
+		for j, to := range req.Criteria {
+			if total[i][j] > result.Threshold {
+				result.Links = append(result.Links, helperTopsis.InfluenceLink{
+					From:  criterion.Name,
+					To:    to.Name,
+					Value: total[i][j],
+				})
+			}
+		}
+	}
+
+	if req.DeriveWeights {
+		result.Weights = make(map[string]float64, n)
+		result.WeightedCriteria = make([]helperTopsis.Criterion, n)
+		copy(result.WeightedCriteria, req.Criteria)
+		for i, criterion := range req.Criteria {
+			result.Weights[criterion.Name] = weights[i] / weightSum
+			result.WeightedCriteria[i].Weight = weights[i] / weightSum
+		}
+	}
+	return result, nil
+}
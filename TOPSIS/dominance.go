@@ -0,0 +1,36 @@
+package topsis
+
+import "github.com/nabilulilalbab/TopsisByme/helperTopsis"
+
+// applyDominance menghitung relasi dominasi sebelum ranking. Pada mode
+// exclude, alternatif yang terdominasi dibuang dari request yang dikembalikan.
+func applyDominance(
+	req helperTopsis.TOPSISRequest,
+) (helperTopsis.TOPSISRequest, *helperTopsis.DominanceReport) {
+	dominatedBy := helperTopsis.DetermineDominance(req.Alternatives, req.Criteria)
+	report := &helperTopsis.DominanceReport{
+		ParetoSet: make([]string, 0, len(req.Alternatives)-len(dominatedBy)),
+		Dominated: dominatedBy,
+	}
+	efficient := make([]helperTopsis.Alternative, 0, len(req.Alternatives))
+	for _, alt := range req.Alternatives {
+		if _, dominated := dominatedBy[alt.Name]; dominated {
+			if req.Dominance == helperTopsis.DominanceExclude {
+				report.Excluded = append(report.Excluded, alt.Name)
+			}
+			continue
+		}
+		report.ParetoSet = append(report.ParetoSet, alt.Name)
+		efficient = append(efficient, alt)
+	}
+	if req.Dominance == helperTopsis.DominanceExclude {
+		req.Alternatives = efficient
+	}
+	return req, report
+}
+
+func markDominance(results []helperTopsis.TOPSISResult, report *helperTopsis.DominanceReport) {
+	for i := range results {
+		results[i].DominatedBy = report.Dominated[results[i].Name]
+	}
+}
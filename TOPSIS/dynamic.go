@@ -0,0 +1,210 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const DefaultDecayRate = 0.5
+
+// DynamicTopsis meranking deret waktu matriks keputusan. Setiap periode
+// diranking sendiri, lalu matriks semua periode dirata-rata dengan bobot waktu
+// dan diranking sekali lagi sebagai hasil agregat. Semua periode harus berisi
+// alternatif yang sama. Peringkat 0 pada trajectory berarti alternatif gugur
+// pada periode tersebut karena batas kriteria, kebijakan nilai kosong
+// exclude, atau ekspresi yang gagal dihitung. Alternatif yang dibuang kedua
+// cara terakhir tidak punya nilai pada periode itu, jadi tidak ikut hasil
+// agregat; alasannya dicatat pada Missing dan ExpressionErrors periodenya.
+func DynamicTopsis(req helperTopsis.DynamicTOPSISRequest) (helperTopsis.DynamicTOPSISResponse, error) {
+	if len(req.Periods) == 0 {
+		return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf("No periods Provided")
+	}
+	if len(req.Alternatives) > 0 {
+		return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf("alternatives must be given per period")
+	}
+	weights, err := timeWeights(req)
+	if err != nil {
+		return helperTopsis.DynamicTOPSISResponse{}, err
+	}
+
+	names := make([]string, 0, len(req.Periods[0].Alternatives))
+	for _, alt := range req.Periods[0].Alternatives {
+		names = append(names, alt.Name)
+	}
+	for t, period := range req.Periods {
+		present := make(map[string]bool, len(period.Alternatives))
+		for _, alt := range period.Alternatives {
+			present[alt.Name] = true
+		}
+		label := periodLabel(period, t)
+		if len(present) != len(names) {
+			return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf(
+				"period %s has %d alternatives, expected %d", label, len(present), len(names))
+		}
+		for _, name := range names {
+			if !present[name] {
+				return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf(
+					"period %s is missing alternative %s", label, name)
+			}
+		}
+	}
+
+	response := helperTopsis.DynamicTOPSISResponse{
+		Periods: make([]helperTopsis.PeriodRanking, 0, len(req.Periods)),
+	}
+	periodValues := make([]map[string]map[string]float64, len(req.Periods))
+	// kriteria setelah pra-proses: pohon diratakan, bobot dibangkitkan, kolom bantu dibuang
+	var criteria []helperTopsis.Criterion
+	for t, period := range req.Periods {
+		label := periodLabel(period, t)
+		periodReq := req.TOPSISRequest
+		periodReq.Alternatives = period.Alternatives
+		// pengelompokan hanya untuk hasil agregat
+		periodReq.Clustering = nil
+		// nilai numerik setelah label dan nilai kosong diproses, untuk matriks agregat
+		scaled, prep, err := prepareRequest(periodReq)
+		if err != nil {
+			return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf("period %s: %w", label, err)
+		}
+		criteria = scaled.Criteria
+		periodValues[t] = make(map[string]map[string]float64, len(scaled.Alternatives))
+		for _, alt := range scaled.Alternatives {
+			periodValues[t][alt.Name] = alt.Values
+		}
+		periodResponse, err := rankPrepared(scaled, prep)
+		if err != nil {
+			return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf("period %s: %w", label, err)
+		}
+		response.Periods = append(response.Periods, helperTopsis.PeriodRanking{
+			Label:            label,
+			Weight:           weights[t],
+			Results:          periodResponse.Results,
+			Missing:          periodResponse.Missing,
+			ExpressionErrors: periodResponse.ExpressionErrors,
+		})
+	}
+
+	// matriks agregat sudah berupa angka kanonik, jadi pra-proses yang
+	// mengubah nilai atau bobot tidak dijalankan lagi
+	aggregated := req.TOPSISRequest
+	aggregated.CriteriaTree = nil
+	aggregated.Weighting = nil
+	aggregated.MissingPolicy = ""
+	aggregated.Criteria = make([]helperTopsis.Criterion, len(criteria))
+	for i, criterion := range criteria {
+		criterion.Expression = ""
+		criterion.Scale = nil
+		criterion.MissingPolicy = ""
+		aggregated.Criteria[i] = criterion
+	}
+	aggregated.Alternatives = make([]helperTopsis.Alternative, 0, len(names))
+	for _, name := range names {
+		if !presentInAll(periodValues, name) {
+			continue
+		}
+		values := make(map[string]float64, len(criteria))
+		for _, criterion := range criteria {
+			for t := range req.Periods {
+				values[criterion.Name] += weights[t] * periodValues[t][name][criterion.Name]
+			}
+		}
+		aggregated.Alternatives = append(aggregated.Alternatives, helperTopsis.Alternative{Name: name, Values: values})
+	}
+	if len(aggregated.Alternatives) == 0 {
+		return helperTopsis.DynamicTOPSISResponse{}, fmt.Errorf("No Alternative has values in every period")
+	}
+	response.Aggregate, err = Topsis(aggregated)
+	if err != nil {
+		return helperTopsis.DynamicTOPSISResponse{}, err
+	}
+
+	// trajectory diurutkan sesuai hasil agregat, alternatif yang gugur di akhir
+	ordered := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, result := range response.Aggregate.Results {
+		ordered = append(ordered, result.Name)
+		seen[result.Name] = true
+	}
+	for _, name := range names {
+		if !seen[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	response.Trajectories = make([]helperTopsis.RankTrajectory, 0, len(ordered))
+	for _, name := range ordered {
+		trajectory := helperTopsis.RankTrajectory{
+			Name:            name,
+			Ranks:           make([]int, len(response.Periods)),
+			ClosenessValues: make([]float64, len(response.Periods)),
+		}
+		for t, period := range response.Periods {
+			if result, ok := findResult(period.Results, name); ok {
+				trajectory.Ranks[t] = result.Rank
+				trajectory.ClosenessValues[t] = result.ClosenessValue
+			}
+		}
+		response.Trajectories = append(response.Trajectories, trajectory)
+	}
+	return response, nil
+}
+
+func periodLabel(period helperTopsis.TOPSISPeriod, t int) string {
+	if period.Label != "" {
+		return period.Label
+	}
+	return strconv.Itoa(t + 1)
+}
+
+func presentInAll(periodValues []map[string]map[string]float64, name string) bool {
+	for _, values := range periodValues {
+		if _, ok := values[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func timeWeights(req helperTopsis.DynamicTOPSISRequest) ([]float64, error) {
+	weights := make([]float64, len(req.Periods))
+	switch req.TimeWeighting {
+	case "", helperTopsis.TimeWeightingEqual:
+		for t := range weights {
+			weights[t] = 1
+		}
+	case helperTopsis.TimeWeightingExponential:
+		decay := req.DecayRate
+		if decay == 0 {
+			decay = DefaultDecayRate
+		}
+		if decay < 0 || decay > 1 {
+			return nil, fmt.Errorf("decayRate must be between 0 and 1")
+		}
+		for t := range weights {
+			weights[t] = math.Pow(decay, float64(len(weights)-1-t))
+		}
+	case helperTopsis.TimeWeightingCustom:
+		for t, period := range req.Periods {
+			if period.Weight < 0 {
+				return nil, fmt.Errorf("period %d has negative weight", t+1)
+			}
+			weights[t] = period.Weight
+		}
+	default:
+		return nil, fmt.Errorf("Invalid time weighting : %s", req.TimeWeighting)
+	}
+
+	sum := 0.0
+	for _, weight := range weights {
+		sum += weight
+	}
+	if sum <= 0 {
+		return nil, fmt.Errorf("time weights sum to zero")
+	}
+	for t := range weights {
+		weights[t] /= sum
+	}
+	return weights, nil
+}
@@ -0,0 +1,169 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const (
+	favorsNone = "none"
+	// jumlah kriteria yang disebut di ringkasan
+	summaryCriteriaLimit = 3
+	contributionEpsilon  = 1e-12
+)
+
+// ExplainPair menjelaskan mengapa satu alternatif berada di atas alternatif
+// lain. Selisih kuadrat jarak dipecah per kriteria lalu dibagi jumlah kedua
+// jarak (a²-b² = (a-b)(a+b)), sehingga kontribusi per kriteria dijumlahkan
+// tepat menjadi selisih jarak ke solusi ideal positif dan negatif.
+func ExplainPair(req helperTopsis.ExplainRequest) (helperTopsis.PairwiseExplanation, error) {
+	language := req.Language
+	if language == "" {
+		language = helperTopsis.LanguageIndonesian
+	}
+	if language != helperTopsis.LanguageIndonesian && language != helperTopsis.LanguageEnglish {
+		return helperTopsis.PairwiseExplanation{}, fmt.Errorf("Invalid language : %s", req.Language)
+	}
+	if req.Distance == helperTopsis.DistanceMahalanobis {
+		return helperTopsis.PairwiseExplanation{}, fmt.Errorf("explanation is only available for euclidean distance")
+	}
+	if req.AlternativeA == req.AlternativeB {
+		return helperTopsis.PairwiseExplanation{}, fmt.Errorf("alternativeA and alternativeB must be different")
+	}
+
+	// kontribusi dihitung per kriteria daun, jadi pohon kriteria diratakan dulu
+	topsisReq, err := applyCriteriaTree(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.PairwiseExplanation{}, err
+	}
+	topsisReq.CriteriaTree = nil
+	response, err := Topsis(topsisReq)
+	if err != nil {
+		return helperTopsis.PairwiseExplanation{}, err
+	}
+	resultA, ok := findResult(response.Results, req.AlternativeA)
+	if !ok {
+		return helperTopsis.PairwiseExplanation{}, fmt.Errorf("Alternative %s not found in results", req.AlternativeA)
+	}
+	resultB, ok := findResult(response.Results, req.AlternativeB)
+	if !ok {
+		return helperTopsis.PairwiseExplanation{}, fmt.Errorf("Alternative %s not found in results", req.AlternativeB)
+	}
+
+	explanation := helperTopsis.PairwiseExplanation{
+		AlternativeA:         resultA.Name,
+		AlternativeB:         resultB.Name,
+		RankA:                resultA.Rank,
+		RankB:                resultB.Rank,
+		ClosenessA:           resultA.ClosenessValue,
+		ClosenessB:           resultB.ClosenessValue,
+		PositiveDistanceDiff: resultA.PositiveDistance - resultB.PositiveDistance,
+		NegativeDistanceDiff: resultA.NegativeDistance - resultB.NegativeDistance,
+		Contributions:        make([]helperTopsis.CriterionContribution, 0, len(topsisReq.Criteria)),
+	}
+	positiveSum := resultA.PositiveDistance + resultB.PositiveDistance
+	negativeSum := resultA.NegativeDistance + resultB.NegativeDistance
+	for _, criterion := range helperTopsis.RankedCriteria(topsisReq.Criteria) {
+		valueA := resultA.WeightedValues[criterion.Name]
+		valueB := resultB.WeightedValues[criterion.Name]
+		idealPositive := response.IdealPositive[criterion.Name]
+		idealNegative := response.IdealNegative[criterion.Name]
+
+		positiveContribution := 0.0
+		if positiveSum > 0 {
+			positiveContribution = (math.Pow(valueA-idealPositive, 2) - math.Pow(valueB-idealPositive, 2)) / positiveSum
+		}
+		negativeContribution := 0.0
+		if negativeSum > 0 {
+			negativeContribution = (math.Pow(valueA-idealNegative, 2) - math.Pow(valueB-idealNegative, 2)) / negativeSum
+		}
+		// A diuntungkan jika lebih dekat ke ideal positif dan lebih jauh dari ideal negatif
+		impact := negativeContribution - positiveContribution
+		favors := favorsNone
+		if impact > contributionEpsilon {
+			favors = resultA.Name
+		} else if impact < -contributionEpsilon {
+			favors = resultB.Name
+		}
+		explanation.Contributions = append(explanation.Contributions, helperTopsis.CriterionContribution{
+			Criterion:                    criterion.Name,
+			WeightedValueA:               valueA,
+			WeightedValueB:               valueB,
+			PositiveDistanceContribution: positiveContribution,
+			NegativeDistanceContribution: negativeContribution,
+			Impact:                       impact,
+			Favors:                       favors,
+		})
+	}
+	sort.SliceStable(explanation.Contributions, func(i, j int) bool {
+		return math.Abs(explanation.Contributions[i].Impact) > math.Abs(explanation.Contributions[j].Impact)
+	})
+	explanation.Summary = summarizePair(explanation, language)
+	return explanation, nil
+}
+
+func findResult(results []helperTopsis.TOPSISResult, name string) (helperTopsis.TOPSISResult, bool) {
+	for _, result := range results {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return helperTopsis.TOPSISResult{}, false
+}
+
+func summarizePair(explanation helperTopsis.PairwiseExplanation, language string) string {
+	a, b := explanation.AlternativeA, explanation.AlternativeB
+	var opening string
+	switch {
+	case explanation.ClosenessA == explanation.ClosenessB:
+		if language == helperTopsis.LanguageEnglish {
+			opening = fmt.Sprintf("%s and %s have the same closeness (%.4f).", a, b, explanation.ClosenessA)
+		} else {
+			opening = fmt.Sprintf("%s dan %s memiliki nilai closeness yang sama (%.4f).", a, b, explanation.ClosenessA)
+		}
+	case explanation.ClosenessA > explanation.ClosenessB:
+		if language == helperTopsis.LanguageEnglish {
+			opening = fmt.Sprintf("%s ranks above %s (rank %d vs %d, closeness %.4f vs %.4f).",
+				a, b, explanation.RankA, explanation.RankB, explanation.ClosenessA, explanation.ClosenessB)
+		} else {
+			opening = fmt.Sprintf("%s berada di atas %s (peringkat %d vs %d, closeness %.4f vs %.4f).",
+				a, b, explanation.RankA, explanation.RankB, explanation.ClosenessA, explanation.ClosenessB)
+		}
+	default:
+		if language == helperTopsis.LanguageEnglish {
+			opening = fmt.Sprintf("%s ranks below %s (rank %d vs %d, closeness %.4f vs %.4f).",
+				a, b, explanation.RankA, explanation.RankB, explanation.ClosenessA, explanation.ClosenessB)
+		} else {
+			opening = fmt.Sprintf("%s berada di bawah %s (peringkat %d vs %d, closeness %.4f vs %.4f).",
+				a, b, explanation.RankA, explanation.RankB, explanation.ClosenessA, explanation.ClosenessB)
+		}
+	}
+
+	factors := make([]string, 0, summaryCriteriaLimit)
+	for _, contribution := range explanation.Contributions {
+		if len(factors) == summaryCriteriaLimit || contribution.Favors == favorsNone {
+			break
+		}
+		if language == helperTopsis.LanguageEnglish {
+			factors = append(factors, fmt.Sprintf("%s favoring %s (%+.4f)",
+				contribution.Criterion, contribution.Favors, contribution.Impact))
+		} else {
+			factors = append(factors, fmt.Sprintf("%s menguntungkan %s (%+.4f)",
+				contribution.Criterion, contribution.Favors, contribution.Impact))
+		}
+	}
+	if len(factors) == 0 {
+		if language == helperTopsis.LanguageEnglish {
+			return opening + " No criterion separates them."
+		}
+		return opening + " Tidak ada kriteria yang membedakan keduanya."
+	}
+	if language == helperTopsis.LanguageEnglish {
+		return opening + " The criteria with the largest impact are: " + strings.Join(factors, ", ") + "."
+	}
+	return opening + " Kriteria dengan pengaruh terbesar: " + strings.Join(factors, ", ") + "."
+}
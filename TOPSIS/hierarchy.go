@@ -0,0 +1,123 @@
+package topsis
+
+import (
+	"fmt"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// applyCriteriaTree mengisi req.Criteria dari pohon kriteria bila ada.
+func applyCriteriaTree(req helperTopsis.TOPSISRequest) (helperTopsis.TOPSISRequest, error) {
+	if len(req.CriteriaTree) == 0 {
+		return req, nil
+	}
+	if len(req.Criteria) > 0 {
+		return req, fmt.Errorf("use either criteria or criteriaTree, not both")
+	}
+	criteria, err := helperTopsis.FlattenCriteriaTree(req.CriteriaTree)
+	if err != nil {
+		return req, err
+	}
+	req.Criteria = criteria
+	return req, nil
+}
+
+// branchResults menghitung closeness per sub-pohon. Jarak hanya dijumlahkan
+// atas daun di bawah cabang tersebut; karena closeness tidak berubah bila
+// semua bobot dikali konstanta, bobot global bisa dipakai langsung tanpa
+// dinormalisasi ulang per cabang. Jika ranking keseluruhan memakai jarak
+// Mahalanobis, cabang memakai kovarians daunnya saja (sub-matriks Σ), sama
+// seperti TOPSIS yang hanya berisi kriteria cabang itu.
+func branchResults(
+	req helperTopsis.TOPSISRequest,
+	response helperTopsis.TOPSISResponse,
+) []helperTopsis.BranchResult {
+	alternatives := make([]helperTopsis.Alternative, 0, len(response.Results))
+	normalizedMatrix := make(map[string]map[string]float64, len(response.Results))
+	for _, result := range response.Results {
+		alternatives = append(alternatives, helperTopsis.Alternative{Name: result.Name})
+		normalizedMatrix[result.Name] = result.NormalizedValues
+	}
+	criteria := make(map[string]helperTopsis.Criterion, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		criteria[criterion.Name] = criterion
+	}
+	// kovarians singular membuat ranking keseluruhan kembali ke Euclidean
+	mahalanobis := req.Distance == helperTopsis.DistanceMahalanobis
+	for _, warning := range response.Warnings {
+		if warning.Code == helperTopsis.WarningSingularCovariance {
+			mahalanobis = false
+		}
+	}
+
+	var branches []helperTopsis.BranchResult
+	var walk func(nodes []helperTopsis.CriterionNode, parentWeight float64, level int)
+	walk = func(nodes []helperTopsis.CriterionNode, parentWeight float64, level int) {
+		for _, node := range nodes {
+			if len(node.Children) == 0 {
+				continue
+			}
+			globalWeight := parentWeight * node.Weight
+			leaves := collectLeaves(node)
+			weightedMatrix := make(map[string]map[string]float64, len(response.Results))
+			for _, result := range response.Results {
+				weightedMatrix[result.Name] = make(map[string]float64, len(leaves))
+				for _, leaf := range leaves {
+					weightedMatrix[result.Name][leaf] = result.WeightedValues[leaf]
+				}
+			}
+			branchReq := req
+			branchReq.Criteria = make([]helperTopsis.Criterion, 0, len(leaves))
+			for _, leaf := range leaves {
+				branchReq.Criteria = append(branchReq.Criteria, criteria[leaf])
+			}
+			var inverse [][]float64
+			if mahalanobis {
+				inverse, _ = inverseCovariance(branchReq, normalizedMatrix)
+			}
+			positiveDistances, negativeDistances := separationMeasures(
+				branchReq.Criteria,
+				inverse,
+				weightedMatrix,
+				response.IdealPositive,
+				response.IdealNegative,
+			)
+			ranked := helperTopsis.CalculateClosenessAndRank(
+				alternatives,
+				positiveDistances,
+				negativeDistances,
+				nil,
+				nil,
+			)
+			branch := helperTopsis.BranchResult{
+				Branch:       node.Name,
+				Level:        level,
+				GlobalWeight: globalWeight,
+				Criteria:     leaves,
+				Results:      make([]helperTopsis.BranchScore, 0, len(ranked)),
+			}
+			for _, result := range ranked {
+				branch.Results = append(branch.Results, helperTopsis.BranchScore{
+					Name:           result.Name,
+					ClosenessValue: result.ClosenessValue,
+					Rank:           result.Rank,
+				})
+			}
+			branches = append(branches, branch)
+			walk(node.Children, globalWeight, level+1)
+		}
+	}
+	walk(req.CriteriaTree, 1.0, 1)
+	return branches
+}
+
+func collectLeaves(node helperTopsis.CriterionNode) []string {
+	if len(node.Children) == 0 {
+		return []string{node.Name}
+	}
+	var leaves []string
+	for _, child := range node.Children {
+		leaves = append(leaves, collectLeaves(child)...)
+	}
+	return leaves
+}
@@ -0,0 +1,293 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const (
+	// jumlah langkah untuk menempuh seluruh rentang nilai satu kriteria
+	improvementSteps = 50
+	// iterasi bisection saat memangkas perubahan yang berlebihan
+	improvementShrinkIterations = 30
+)
+
+// SuggestImprovement mencari perubahan nilai terkecil agar alternatif yang
+// dipilih mencapai peringkat target. Pencarian dilakukan secara greedy: setiap
+// langkah menggeser satu kriteria ke arah yang lebih baik (naik untuk benefit,
+// turun untuk cost) yang memberi kenaikan margin terbesar per unit perubahan
+// relatif. Setelah target tercapai, setiap perubahan dipangkas dengan bisection
+// sejauh peringkat target masih terpenuhi.
+//
+// Batas nilai default adalah rentang nilai yang teramati pada semua
+// alternatif, dipersempit oleh Min/Max kriteria dan Bounds pada request.
+// Nilai awal di luar batas tidak dijepit, hanya tidak digeser ke arah yang
+// lebih buruk. Kriteria turunan tidak bisa diubah langsung, jadi tidak ikut
+// dicari dan nilainya dihitung ulang dari kolom lain setiap evaluasi.
+func SuggestImprovement(req helperTopsis.ImprovementRequest) (helperTopsis.ImprovementSuggestion, error) {
+	prepared, _, err := prepareRequest(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.ImprovementSuggestion{}, err
+	}
+	req.TOPSISRequest = prepared
+
+	alternatives, err := targetFirst(req.TOPSISRequest, req.Alternative)
+	if err != nil {
+		return helperTopsis.ImprovementSuggestion{}, err
+	}
+	target := alternatives[0]
+	if req.TargetRank < 1 || req.TargetRank > len(alternatives) {
+		return helperTopsis.ImprovementSuggestion{}, fmt.Errorf(
+			"targetRank must be between 1 and %d", len(alternatives))
+	}
+
+	criteriaNames := make(map[string]bool)
+	for _, criterion := range req.Criteria {
+		criteriaNames[criterion.Name] = true
+	}
+	fixed := make(map[string]bool)
+	for _, name := range req.Fixed {
+		if !criteriaNames[name] {
+			return helperTopsis.ImprovementSuggestion{}, fmt.Errorf("unknown fixed criteria %s", name)
+		}
+		fixed[name] = true
+	}
+	for name := range req.Bounds {
+		if !criteriaNames[name] {
+			return helperTopsis.ImprovementSuggestion{}, fmt.Errorf("bounds given for unknown criteria %s", name)
+		}
+	}
+
+	lower := make(map[string]float64)
+	upper := make(map[string]float64)
+	for _, criterion := range req.Criteria {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, alt := range alternatives {
+			low = math.Min(low, alt.Values[criterion.Name])
+			high = math.Max(high, alt.Values[criterion.Name])
+		}
+		if criterion.Min != nil {
+			low = math.Max(low, *criterion.Min)
+		}
+		if criterion.Max != nil {
+			high = math.Min(high, *criterion.Max)
+		}
+		if bounds, ok := req.Bounds[criterion.Name]; ok {
+			if bounds.Min != nil {
+				low = math.Max(low, *bounds.Min)
+			}
+			if bounds.Max != nil {
+				high = math.Min(high, *bounds.Max)
+			}
+		}
+		if low > high && !fixed[criterion.Name] {
+			return helperTopsis.ImprovementSuggestion{}, fmt.Errorf("bounds for criteria %s are empty", criterion.Name)
+		}
+		lower[criterion.Name] = low
+		upper[criterion.Name] = high
+	}
+
+	search := improvementSearch{
+		req: helperTopsis.TOPSISRequest{
+			Criteria:      req.Criteria,
+			Alternatives:  alternatives,
+			Normalization: req.Normalization,
+			Distance:      req.Distance,
+			Covariance:    req.Covariance,
+		},
+		targetRank: req.TargetRank,
+	}
+	original := target.Values
+	originalCloseness, originalRank, _ := search.evaluate(original)
+
+	current := copyValues(original)
+	closeness, rank, margin := search.evaluate(current)
+	for step := 0; rank > req.TargetRank && step < improvementSteps*len(req.Criteria); step++ {
+		bestName := ""
+		bestGain := 0.0
+		var bestValue, bestCloseness, bestMargin float64
+		var bestRank int
+		for _, criterion := range req.Criteria {
+			scale := upper[criterion.Name] - lower[criterion.Name]
+			if fixed[criterion.Name] || criterion.Expression != "" || scale <= 0 {
+				continue
+			}
+			direction := 1.0
+			if criterion.Type == helperTopsis.Cost {
+				direction = -1.0
+			}
+			next := clamp(
+				current[criterion.Name]+direction*scale/improvementSteps,
+				lower[criterion.Name],
+				upper[criterion.Name],
+			)
+			if (next-current[criterion.Name])*direction <= 0 {
+				continue
+			}
+			candidate := copyValues(current)
+			candidate[criterion.Name] = next
+			candidateCloseness, candidateRank, candidateMargin := search.evaluate(candidate)
+			gain := (candidateMargin - margin) / (math.Abs(next-current[criterion.Name]) / scale)
+			if gain > bestGain {
+				bestName, bestGain, bestValue = criterion.Name, gain, next
+				bestCloseness, bestRank, bestMargin = candidateCloseness, candidateRank, candidateMargin
+			}
+		}
+		if bestName == "" {
+			break
+		}
+		current[bestName] = bestValue
+		closeness, rank, margin = bestCloseness, bestRank, bestMargin
+	}
+
+	achieved := rank <= req.TargetRank
+	if achieved {
+		for _, criterion := range req.Criteria {
+			from, to := original[criterion.Name], current[criterion.Name]
+			if criterion.Expression != "" || from == to {
+				continue
+			}
+			// cari titik paling dekat ke nilai awal yang masih memenuhi target
+			low, high := 0.0, 1.0
+			for i := 0; i < improvementShrinkIterations; i++ {
+				mid := (low + high) / 2
+				candidate := copyValues(current)
+				candidate[criterion.Name] = from + mid*(to-from)
+				if _, candidateRank, _ := search.evaluate(candidate); candidateRank <= req.TargetRank {
+					high = mid
+				} else {
+					low = mid
+				}
+			}
+			current[criterion.Name] = from + high*(to-from)
+		}
+		closeness, rank, _ = search.evaluate(current)
+	}
+	if derived, ok := search.derive(current); ok {
+		current = derived
+	}
+
+	suggestion := helperTopsis.ImprovementSuggestion{
+		Alternative:        target.Name,
+		TargetRank:         req.TargetRank,
+		Achieved:           achieved,
+		OriginalRank:       originalRank,
+		ResultingRank:      rank,
+		OriginalCloseness:  originalCloseness,
+		ResultingCloseness: closeness,
+		Changes:            make([]helperTopsis.ValueChange, 0),
+		Values:             current,
+	}
+	for _, criterion := range req.Criteria {
+		from, to := original[criterion.Name], current[criterion.Name]
+		if criterion.Expression != "" || from == to {
+			continue
+		}
+		suggestion.Changes = append(suggestion.Changes, helperTopsis.ValueChange{
+			Criterion: criterion.Name,
+			From:      from,
+			To:        to,
+			Delta:     to - from,
+		})
+		if scale := upper[criterion.Name] - lower[criterion.Name]; scale > 0 {
+			suggestion.Distance += math.Abs(to-from) / scale
+		}
+	}
+	return suggestion, nil
+}
+
+// improvementSearch menghitung ulang TOPSIS dengan nilai alternatif target
+// (selalu di indeks 0) diganti nilai kandidat.
+type improvementSearch struct {
+	req        helperTopsis.TOPSISRequest
+	targetRank int
+}
+
+// evaluate mengembalikan closeness dan peringkat target, serta margin
+// terhadap alternatif lain di posisi targetRank. Margin >= 0 berarti target
+// tercapai.
+func (s improvementSearch) evaluate(values map[string]float64) (float64, int, float64) {
+	values, ok := s.derive(values)
+	if !ok {
+		return 0, len(s.req.Alternatives), math.Inf(-1)
+	}
+	req := s.req
+	req.Alternatives = make([]helperTopsis.Alternative, len(s.req.Alternatives))
+	copy(req.Alternatives, s.req.Alternatives)
+	req.Alternatives[0] = helperTopsis.Alternative{Name: s.req.Alternatives[0].Name, Values: values}
+
+	response := calculate(req)
+	closeness := 0.0
+	others := make([]float64, 0, len(response.Results))
+	targetSeen := false
+	for _, result := range response.Results {
+		if result.Name == req.Alternatives[0].Name && !targetSeen {
+			closeness = result.ClosenessValue
+			targetSeen = true
+			continue
+		}
+		others = append(others, result.ClosenessValue)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(others)))
+
+	rank := 1
+	for _, value := range others {
+		if value > closeness {
+			rank++
+		}
+	}
+	margin := 1.0
+	if s.targetRank-1 < len(others) {
+		margin = closeness - others[s.targetRank-1]
+	}
+	return closeness, rank, margin
+}
+
+// derive menghitung ulang kriteria turunan dari nilai kandidat. ok false
+// berarti ekspresinya gagal, misalnya pembagian dengan nol.
+func (s improvementSearch) derive(values map[string]float64) (map[string]float64, bool) {
+	derived, errs := helperTopsis.DeriveCriteria(helperTopsis.TOPSISRequest{
+		Criteria:     s.req.Criteria,
+		Alternatives: []helperTopsis.Alternative{{Name: s.req.Alternatives[0].Name, Values: values}},
+	})
+	if len(errs) > 0 {
+		return nil, false
+	}
+	return derived.Alternatives[0].Values, true
+}
+
+// targetFirst memindahkan alternatif target ke indeks 0. Alternatif lain yang
+// gugur karena batas kriteria dibuang agar peringkatnya sama dengan Topsis.
+func targetFirst(req helperTopsis.TOPSISRequest, name string) ([]helperTopsis.Alternative, error) {
+	var target helperTopsis.Alternative
+	others := make([]helperTopsis.Alternative, 0, len(req.Alternatives))
+	found := false
+	for _, alt := range req.Alternatives {
+		if alt.Name == name && !found {
+			target = alt
+			found = true
+			continue
+		}
+		others = append(others, alt)
+	}
+	if !found {
+		return nil, fmt.Errorf("Alternative %s not found", name)
+	}
+	others, _ = helperTopsis.CheckConstraints(others, req.Criteria)
+	return append([]helperTopsis.Alternative{target}, others...), nil
+}
+
+func clamp(value, low, high float64) float64 {
+	return math.Max(low, math.Min(high, value))
+}
+
+func copyValues(values map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(values))
+	for name, value := range values {
+		copied[name] = value
+	}
+	return copied
+}
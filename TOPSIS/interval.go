@@ -0,0 +1,130 @@
+package topsis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// IntervalTopsis adalah TOPSIS untuk nilai rentang (Jahanshahloo dkk.).
+// Setiap ujung interval dibagi sqrt(Σ lower² + upper²), solusi ideal positif
+// adalah upper terbesar untuk benefit dan lower terkecil untuk cost (ideal
+// negatif sebaliknya). Jarak ke ideal menjadi interval: batas bawah memakai
+// ujung terdekat, batas atas ujung terjauh, sehingga closeness juga berupa
+// interval [d⁻min / (d⁻min + d⁺max), d⁻max / (d⁻max + d⁺min)]. Nilai tanpa
+// rentang diperlakukan sebagai interval selebar nol.
+func IntervalTopsis(req helperTopsis.IntervalTOPSISRequest) (helperTopsis.IntervalTOPSISResponse, error) {
+	if err := helperTopsis.ValidateIntervalRequest(req); err != nil {
+		return helperTopsis.IntervalTOPSISResponse{}, err
+	}
+	prepared, _, err := prepareRequest(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.IntervalTOPSISResponse{}, err
+	}
+	var rejected []helperTopsis.RejectedAlternative
+	prepared.Alternatives, rejected = helperTopsis.CheckConstraints(prepared.Alternatives, prepared.Criteria)
+
+	comparison := req.Comparison
+	if comparison == "" {
+		comparison = helperTopsis.IntervalMidpoint
+	}
+	response := helperTopsis.IntervalTOPSISResponse{
+		Comparison:           comparison,
+		Results:              make([]helperTopsis.IntervalTOPSISResult, 0, len(prepared.Alternatives)),
+		IdealPositive:        make(map[string]float64, len(prepared.Criteria)),
+		IdealNegative:        make(map[string]float64, len(prepared.Criteria)),
+		NormalizationFactors: make(map[string]float64, len(prepared.Criteria)),
+		Rejected:             rejected,
+	}
+	if len(prepared.Alternatives) == 0 {
+		return response, nil
+	}
+
+	raw := make([]map[string]helperTopsis.Interval, len(prepared.Alternatives))
+	for i, alt := range prepared.Alternatives {
+		raw[i] = helperTopsis.IntervalValues(alt, prepared.Criteria)
+	}
+	weighted := make([]map[string]helperTopsis.Interval, len(prepared.Alternatives))
+	for i := range weighted {
+		weighted[i] = make(map[string]helperTopsis.Interval, len(prepared.Criteria))
+	}
+	for _, criterion := range prepared.Criteria {
+		sumOfSquares := 0.0
+		for i := range raw {
+			value := raw[i][criterion.Name]
+			sumOfSquares += value.Lower*value.Lower + value.Upper*value.Upper
+		}
+		factor := math.Sqrt(sumOfSquares)
+		response.NormalizationFactors[criterion.Name] = factor
+
+		highest, lowest := math.Inf(-1), math.Inf(1)
+		for i := range raw {
+			value := helperTopsis.Interval{}
+			if factor > 0 {
+				value = helperTopsis.Interval{
+					Lower: raw[i][criterion.Name].Lower / factor * criterion.Weight,
+					Upper: raw[i][criterion.Name].Upper / factor * criterion.Weight,
+				}
+			}
+			weighted[i][criterion.Name] = value
+			highest = math.Max(highest, value.Upper)
+			lowest = math.Min(lowest, value.Lower)
+		}
+		if criterion.Type == helperTopsis.Benefit {
+			response.IdealPositive[criterion.Name], response.IdealNegative[criterion.Name] = highest, lowest
+		} else {
+			response.IdealPositive[criterion.Name], response.IdealNegative[criterion.Name] = lowest, highest
+		}
+	}
+
+	for i, alt := range prepared.Alternatives {
+		positive := helperTopsis.IntervalSeparation(weighted[i], response.IdealPositive)
+		negative := helperTopsis.IntervalSeparation(weighted[i], response.IdealNegative)
+		closeness := helperTopsis.Interval{}
+		if total := negative.Lower + positive.Upper; total > 0 {
+			closeness.Lower = negative.Lower / total
+		}
+		if total := negative.Upper + positive.Lower; total > 0 {
+			closeness.Upper = negative.Upper / total
+		}
+		response.Results = append(response.Results, helperTopsis.IntervalTOPSISResult{
+			Name:             alt.Name,
+			Closeness:        closeness,
+			PositiveDistance: positive,
+			NegativeDistance: negative,
+			WeightedValues:   weighted[i],
+		})
+	}
+
+	for i := range response.Results {
+		closeness := response.Results[i].Closeness
+		switch comparison {
+		case helperTopsis.IntervalLower:
+			response.Results[i].Score = closeness.Lower
+		case helperTopsis.IntervalUpper:
+			response.Results[i].Score = closeness.Upper
+		case helperTopsis.IntervalPossibility:
+			if len(response.Results) == 1 {
+				response.Results[i].Score = 1
+				continue
+			}
+			sum := 0.0
+			for j, other := range response.Results {
+				if i != j {
+					sum += closeness.Possibility(other.Closeness)
+				}
+			}
+			response.Results[i].Score = sum / float64(len(response.Results)-1)
+		default:
+			response.Results[i].Score = closeness.Midpoint()
+		}
+	}
+	sort.SliceStable(response.Results, func(i, j int) bool {
+		return response.Results[i].Score > response.Results[j].Score
+	})
+	for i := range response.Results {
+		response.Results[i].Rank = i + 1
+	}
+	return response, nil
+}
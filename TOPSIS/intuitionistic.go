@@ -0,0 +1,109 @@
+package topsis
+
+import (
+	"math"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// IntuitionisticTopsis adalah TOPSIS fuzzy intuisionistik (Boran dkk.).
+// Penilaian semua panelis digabung per sel dengan operator IFWA, lalu setiap
+// nilai dikalikan bobot IFN kriterianya. Solusi ideal positif mengambil mu
+// terbesar dan nu terkecil untuk benefit (sebaliknya untuk cost), dan jarak
+// dihitung dengan jarak Euclidean ternormalisasi Szmidt-Kacprzyk.
+func IntuitionisticTopsis(req helperTopsis.IFTOPSISRequest) (helperTopsis.IFTOPSISResponse, error) {
+	if err := helperTopsis.ValidateIFTOPSISRequest(req); err != nil {
+		return helperTopsis.IFTOPSISResponse{}, err
+	}
+
+	expertWeights := make([]float64, len(req.Experts))
+	weightSum := 0.0
+	for k, expert := range req.Experts {
+		expertWeights[k] = expert.Weight
+		weightSum += expert.Weight
+	}
+	for k := range expertWeights {
+		if weightSum > 0 {
+			expertWeights[k] /= weightSum
+		} else {
+			expertWeights[k] = 1 / float64(len(req.Experts))
+		}
+	}
+
+	// penilaian panelis diindeks per alternatif karena urutannya boleh berbeda
+	ratings := make([]map[string]map[string]helperTopsis.IFN, len(req.Experts))
+	for k, expert := range req.Experts {
+		ratings[k] = make(map[string]map[string]helperTopsis.IFN, len(expert.Alternatives))
+		for _, alt := range expert.Alternatives {
+			ratings[k][alt.Name] = alt.Values
+		}
+	}
+
+	response := helperTopsis.IFTOPSISResponse{
+		IdealPositive: make(map[string]helperTopsis.IFN, len(req.Criteria)),
+		IdealNegative: make(map[string]helperTopsis.IFN, len(req.Criteria)),
+		ExpertWeights: make(map[string]float64, len(req.Experts)),
+		Aggregated:    make(map[string]map[string]helperTopsis.IFN),
+		Weighted:      make(map[string]map[string]helperTopsis.IFN),
+	}
+	for k, expert := range req.Experts {
+		response.ExpertWeights[expert.Name] = expertWeights[k]
+	}
+
+	alternatives := make([]helperTopsis.Alternative, 0, len(req.Experts[0].Alternatives))
+	scores := make(map[string]map[string]float64)
+	weightedScores := make(map[string]map[string]float64)
+	for _, alt := range req.Experts[0].Alternatives {
+		alternatives = append(alternatives, helperTopsis.Alternative{Name: alt.Name})
+		response.Aggregated[alt.Name] = make(map[string]helperTopsis.IFN, len(req.Criteria))
+		response.Weighted[alt.Name] = make(map[string]helperTopsis.IFN, len(req.Criteria))
+		scores[alt.Name] = make(map[string]float64, len(req.Criteria))
+		weightedScores[alt.Name] = make(map[string]float64, len(req.Criteria))
+		for _, criterion := range req.Criteria {
+			values := make([]helperTopsis.IFN, len(req.Experts))
+			for k := range req.Experts {
+				values[k] = ratings[k][alt.Name][criterion.Name]
+			}
+			aggregated := helperTopsis.IFWA(values, expertWeights)
+			weighted := aggregated.Multiply(criterion.Weight)
+			response.Aggregated[alt.Name][criterion.Name] = aggregated
+			response.Weighted[alt.Name][criterion.Name] = weighted
+			scores[alt.Name][criterion.Name] = aggregated.Score()
+			weightedScores[alt.Name][criterion.Name] = weighted.Score()
+		}
+	}
+
+	for _, criterion := range req.Criteria {
+		best := helperTopsis.IFN{Membership: 0, NonMembership: 1}
+		worst := helperTopsis.IFN{Membership: 1, NonMembership: 0}
+		for _, alt := range alternatives {
+			value := response.Weighted[alt.Name][criterion.Name]
+			best.Membership = math.Max(best.Membership, value.Membership)
+			best.NonMembership = math.Min(best.NonMembership, value.NonMembership)
+			worst.Membership = math.Min(worst.Membership, value.Membership)
+			worst.NonMembership = math.Max(worst.NonMembership, value.NonMembership)
+		}
+		if criterion.Type == helperTopsis.Cost {
+			best, worst = worst, best
+		}
+		response.IdealPositive[criterion.Name] = best
+		response.IdealNegative[criterion.Name] = worst
+	}
+
+	positiveDistances := make(map[string]float64, len(alternatives))
+	negativeDistances := make(map[string]float64, len(alternatives))
+	for _, alt := range alternatives {
+		positiveDistances[alt.Name] = helperTopsis.IFNDistance(
+			response.Weighted[alt.Name], response.IdealPositive, req.Criteria)
+		negativeDistances[alt.Name] = helperTopsis.IFNDistance(
+			response.Weighted[alt.Name], response.IdealNegative, req.Criteria)
+	}
+	response.Results = helperTopsis.CalculateClosenessAndRank(
+		alternatives,
+		positiveDistances,
+		negativeDistances,
+		scores,
+		weightedScores,
+	)
+	return response, nil
+}
@@ -0,0 +1,216 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const (
+	DefaultInverseWeightSamples = 5000
+	MaxInverseWeightSamples     = 100000
+	inverseWeightBisections     = 30
+)
+
+// InverseWeights mencari vektor bobot pada simplex (bobot >= 0, jumlah 1)
+// yang membuat alternatif pilihan menjadi peringkat 1 dengan closeness lebih
+// tinggi dari semua alternatif lain. Titik yang diuji adalah bobot saat ini,
+// setiap sudut simplex, dan sampel acak seragam (Dirichlet(1,...,1)). Bobot
+// terdekat dengan bobot saat ini kemudian diperhalus dengan bisection di
+// sepanjang garis di antara keduanya.
+func InverseWeights(req helperTopsis.InverseWeightRequest) (helperTopsis.InverseWeightResult, error) {
+	prepared, _, err := prepareRequest(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.InverseWeightResult{}, err
+	}
+	req.TOPSISRequest = prepared
+	samples := req.Samples
+	if samples <= 0 {
+		samples = DefaultInverseWeightSamples
+	}
+	if samples > MaxInverseWeightSamples {
+		return helperTopsis.InverseWeightResult{}, fmt.Errorf("samples must not exceed %d", MaxInverseWeightSamples)
+	}
+	alternatives, err := targetFirst(req.TOPSISRequest, req.Alternative)
+	if err != nil {
+		return helperTopsis.InverseWeightResult{}, err
+	}
+
+	evaluatorReq := req.TOPSISRequest
+	evaluatorReq.Alternatives = alternatives
+	evaluator := newWeightEvaluator(evaluatorReq)
+	current := make([]float64, len(req.Criteria))
+	for i, criterion := range req.Criteria {
+		current[i] = criterion.Weight
+	}
+	result := helperTopsis.InverseWeightResult{
+		Alternative:    alternatives[0].Name,
+		CurrentRank:    evaluator.rank(current),
+		CurrentWeights: weightMap(req.Criteria, current),
+	}
+
+	var closest []float64
+	closestDistance := math.Inf(1)
+	lower := make([]float64, len(current))
+	upper := make([]float64, len(current))
+	for i := range lower {
+		lower[i], upper[i] = math.Inf(1), math.Inf(-1)
+	}
+	record := func(weights []float64) {
+		for i, weight := range weights {
+			lower[i] = math.Min(lower[i], weight)
+			upper[i] = math.Max(upper[i], weight)
+		}
+		if distance := euclidean(weights, current); distance < closestDistance {
+			closest, closestDistance = weights, distance
+		}
+	}
+
+	candidates := [][]float64{current}
+	for i := range req.Criteria {
+		vertex := make([]float64, len(req.Criteria))
+		vertex[i] = 1
+		candidates = append(candidates, vertex)
+	}
+	for _, weights := range candidates {
+		result.SamplesEvaluated++
+		if evaluator.rank(weights) == 1 {
+			record(weights)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(req.Seed))
+	feasibleSamples := 0
+	for s := 0; s < samples; s++ {
+		weights := make([]float64, len(req.Criteria))
+		sum := 0.0
+		for i := range weights {
+			weights[i] = rng.ExpFloat64()
+			sum += weights[i]
+		}
+		for i := range weights {
+			weights[i] /= sum
+		}
+		result.SamplesEvaluated++
+		if evaluator.rank(weights) == 1 {
+			feasibleSamples++
+			record(weights)
+		}
+	}
+	result.FeasibleShare = float64(feasibleSamples) / float64(samples)
+
+	if closest == nil {
+		return result, nil
+	}
+	if closestDistance > 0 {
+		// simplex konveks, jadi setiap titik di garis current-closest tetap valid sebagai bobot
+		low, high := 0.0, 1.0
+		for i := 0; i < inverseWeightBisections; i++ {
+			mid := (low + high) / 2
+			if evaluator.rank(interpolate(current, closest, mid)) == 1 {
+				high = mid
+			} else {
+				low = mid
+			}
+			result.SamplesEvaluated++
+		}
+		record(interpolate(current, closest, high))
+	}
+
+	result.Feasible = true
+	result.ClosestWeights = weightMap(req.Criteria, closest)
+	result.Distance = closestDistance
+	result.WeightBounds = make(map[string]helperTopsis.WeightRange, len(req.Criteria))
+	for i, criterion := range req.Criteria {
+		result.WeightBounds[criterion.Name] = helperTopsis.WeightRange{Min: lower[i], Max: upper[i]}
+	}
+	return result, nil
+}
+
+// weightEvaluator menyimpan matriks ternormalisasi karena faktor normalisasi
+// tidak bergantung pada bobot, sehingga hanya langkah berbobot yang dihitung
+// ulang untuk setiap vektor bobot.
+type weightEvaluator struct {
+	criteria         []helperTopsis.Criterion
+	alternatives     []helperTopsis.Alternative
+	normalizedMatrix map[string]map[string]float64
+	inverse          [][]float64
+}
+
+func newWeightEvaluator(req helperTopsis.TOPSISRequest) weightEvaluator {
+	_, normalizedMatrix, _ := helperTopsis.Normalize(req)
+	inverse, _ := inverseCovariance(req, normalizedMatrix)
+	return weightEvaluator{
+		criteria:         req.Criteria,
+		alternatives:     req.Alternatives,
+		normalizedMatrix: normalizedMatrix,
+		inverse:          inverse,
+	}
+}
+
+// closeness menghitung closeness semua alternatif dengan bobot yang diberikan.
+func (e weightEvaluator) closeness(weights []float64) map[string]float64 {
+	criteria := make([]helperTopsis.Criterion, len(e.criteria))
+	copy(criteria, e.criteria)
+	for i := range criteria {
+		criteria[i].Weight = weights[i]
+	}
+	weightedMatrix := helperTopsis.CalculateWeightedNormalizedMatrix(e.normalizedMatrix, criteria)
+	idealPositive, idealNegative := helperTopsis.DetermineIdealSolutions(weightedMatrix, criteria)
+	positiveDistances, negativeDistances := separationMeasures(
+		criteria,
+		e.inverse,
+		weightedMatrix,
+		idealPositive,
+		idealNegative,
+	)
+	closeness := make(map[string]float64, len(positiveDistances))
+	for name, positiveDistance := range positiveDistances {
+		if total := positiveDistance + negativeDistances[name]; total > 0 {
+			closeness[name] = negativeDistances[name] / total
+		} else {
+			closeness[name] = 0
+		}
+	}
+	return closeness
+}
+
+// rank mengembalikan peringkat alternatif target (indeks 0): satu ditambah
+// jumlah alternatif lain dengan closeness lebih besar atau sama.
+func (e weightEvaluator) rank(weights []float64) int {
+	closeness := e.closeness(weights)
+	targetValue := closeness[e.alternatives[0].Name]
+	rank := 1
+	for _, alt := range e.alternatives[1:] {
+		if closeness[alt.Name] >= targetValue {
+			rank++
+		}
+	}
+	return rank
+}
+
+func weightMap(criteria []helperTopsis.Criterion, weights []float64) map[string]float64 {
+	mapped := make(map[string]float64, len(criteria))
+	for i, criterion := range criteria {
+		mapped[criterion.Name] = weights[i]
+	}
+	return mapped
+}
+
+func interpolate(from, to []float64, t float64) []float64 {
+	point := make([]float64, len(from))
+	for i := range from {
+		point[i] = from[i] + t*(to[i]-from[i])
+	}
+	return point
+}
+
+func euclidean(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
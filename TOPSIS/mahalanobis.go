@@ -0,0 +1,43 @@
+package topsis
+
+import "github.com/nabilulilalbab/TopsisByme/helperTopsis"
+
+// inverseCovariance menyiapkan Σ⁻¹ untuk jarak Mahalanobis, dari kovarians
+// yang diberikan atau diestimasi dari matriks ternormalisasi. Hasilnya nil
+// untuk jarak Euclidean, dan nil beserta peringatan bila Σ singular atau
+// tidak definit positif sehingga perhitungan kembali memakai jarak Euclidean.
+func inverseCovariance(
+	req helperTopsis.TOPSISRequest,
+	normalizedMatrix map[string]map[string]float64,
+) ([][]float64, []helperTopsis.CalculationWarning) {
+	if req.Distance != helperTopsis.DistanceMahalanobis {
+		return nil, nil
+	}
+	var covariance [][]float64
+	if req.Covariance != nil {
+		covariance = helperTopsis.CovarianceMatrix(req.Covariance, req.Criteria)
+	} else {
+		covariance = helperTopsis.EstimateCovariance(normalizedMatrix, req.Criteria)
+	}
+	inverse, ok := helperTopsis.InvertMatrix(covariance)
+	if !ok || !helperTopsis.IsPositiveDefinite(covariance) {
+		return nil, []helperTopsis.CalculationWarning{{
+			Code:    helperTopsis.WarningSingularCovariance,
+			Message: "covariance matrix is singular or not positive-definite, falling back to euclidean distance",
+		}}
+	}
+	return inverse, nil
+}
+
+// separationMeasures memilih jarak Mahalanobis bila inverse tersedia.
+func separationMeasures(
+	criteria []helperTopsis.Criterion,
+	inverse [][]float64,
+	weightedMatrix map[string]map[string]float64,
+	idealPositive, idealNegative map[string]float64,
+) (map[string]float64, map[string]float64) {
+	if inverse == nil {
+		return helperTopsis.CalculateSeparationMeasures(weightedMatrix, idealPositive, idealNegative)
+	}
+	return helperTopsis.CalculateMahalanobisSeparation(weightedMatrix, idealPositive, idealNegative, criteria, inverse)
+}
@@ -0,0 +1,189 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// batas simpul branch-and-bound supaya request besar tidak berjalan tanpa akhir
+const MaxPortfolioNodes = 2000000
+
+// SelectPortfolio memilih himpunan alternatif dengan total closeness dari
+// Topsis terbesar yang biayanya muat dalam anggaran dan memenuhi batas jumlah
+// serta kuota kategori. Pencarian memakai branch-and-bound: alternatif
+// diurutkan menurut closeness per biaya, dan cabang dipangkas jika batas atas
+// knapsack pecahan tidak melampaui solusi terbaik atau jika sisa alternatif
+// tidak cukup untuk memenuhi jumlah minimum. Hasilnya eksak; jika dua
+// himpunan sama baiknya, yang lebih murah dipilih.
+func SelectPortfolio(req helperTopsis.PortfolioRequest) (helperTopsis.PortfolioResult, error) {
+	if err := helperTopsis.ValidatePortfolioRequest(req); err != nil {
+		return helperTopsis.PortfolioResult{}, err
+	}
+	response, err := Topsis(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.PortfolioResult{}, err
+	}
+	prepared, _, err := prepareRequest(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.PortfolioResult{}, err
+	}
+	costs, err := helperTopsis.PortfolioCosts(prepared.Alternatives, req.CostAttribute)
+	if err != nil {
+		return helperTopsis.PortfolioResult{}, err
+	}
+	items := make([]helperTopsis.PortfolioItem, 0, len(response.Results))
+	for _, result := range response.Results {
+		items = append(items, helperTopsis.PortfolioItem{
+			Name:      result.Name,
+			Closeness: result.ClosenessValue,
+			Rank:      result.Rank,
+			Cost:      costs[result.Name],
+			Category:  req.Categories[result.Name],
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Closeness*items[j].Cost > items[j].Closeness*items[i].Cost
+	})
+
+	search := portfolioSearch{req: req, items: items, chosen: make([]bool, len(items)), bestValue: -1}
+	// remaining[i][c] adalah jumlah alternatif kategori c di items[i:]
+	search.remaining = make([]map[string]int, len(items)+1)
+	search.remaining[len(items)] = map[string]int{}
+	for i := len(items) - 1; i >= 0; i-- {
+		search.remaining[i] = make(map[string]int, len(search.remaining[i+1])+1)
+		for category, count := range search.remaining[i+1] {
+			search.remaining[i][category] = count
+		}
+		search.remaining[i][items[i].Category]++
+	}
+	search.counts = make(map[string]int)
+	search.byCloseness = make([]int, len(items))
+	for i := range items {
+		search.byCloseness[i] = i
+	}
+	sort.SliceStable(search.byCloseness, func(a, b int) bool {
+		return items[search.byCloseness[a]].Closeness > items[search.byCloseness[b]].Closeness
+	})
+	if err := search.branch(0, 0, 0, 0); err != nil {
+		return helperTopsis.PortfolioResult{}, err
+	}
+	if search.best == nil {
+		return helperTopsis.PortfolioResult{}, fmt.Errorf("no portfolio satisfies the budget, count and quota constraints")
+	}
+
+	result := helperTopsis.PortfolioResult{
+		Selected:      []helperTopsis.PortfolioItem{},
+		Budget:        req.Budget,
+		Rejected:      response.Rejected,
+		NodesExplored: search.nodes,
+	}
+	for i, item := range items {
+		if search.best[i] {
+			result.Selected = append(result.Selected, item)
+			result.TotalCloseness += item.Closeness
+			result.TotalCost += item.Cost
+		} else if result.BestExcluded == nil || item.Closeness > result.BestExcluded.Closeness {
+			excluded := item
+			result.BestExcluded = &excluded
+		}
+	}
+	sort.SliceStable(result.Selected, func(i, j int) bool {
+		return result.Selected[i].Rank < result.Selected[j].Rank
+	})
+	result.Leftover = req.Budget - result.TotalCost
+	return result, nil
+}
+
+type portfolioSearch struct {
+	req       helperTopsis.PortfolioRequest
+	items     []helperTopsis.PortfolioItem
+	remaining []map[string]int
+	counts    map[string]int
+	chosen    []bool
+	// indeks items dari closeness terbesar
+	byCloseness []int
+	best        []bool
+	bestValue   float64
+	bestCost    float64
+	nodes       int
+}
+
+func (s *portfolioSearch) branch(i, count int, cost, value float64) error {
+	s.nodes++
+	if s.nodes > MaxPortfolioNodes {
+		return fmt.Errorf("portfolio search exceeded %d nodes", MaxPortfolioNodes)
+	}
+	if count+len(s.items)-i < s.req.MinCount {
+		return nil
+	}
+	for category, quota := range s.req.Quotas {
+		if s.counts[category]+s.remaining[i][category] < quota.Min {
+			return nil
+		}
+	}
+	if i == len(s.items) {
+		if value > s.bestValue+1e-12 || (math.Abs(value-s.bestValue) <= 1e-12 && cost < s.bestCost) {
+			s.best = append([]bool(nil), s.chosen...)
+			s.bestValue, s.bestCost = value, cost
+		}
+		return nil
+	}
+	if s.best != nil && s.upperBound(i, count, cost, value) < s.bestValue-1e-12 {
+		return nil
+	}
+
+	item := s.items[i]
+	quota, limited := s.req.Quotas[item.Category]
+	fits := cost+item.Cost <= s.req.Budget+1e-9 &&
+		(s.req.MaxCount == 0 || count < s.req.MaxCount) &&
+		(!limited || quota.Max == 0 || s.counts[item.Category] < quota.Max)
+	if fits {
+		s.chosen[i] = true
+		s.counts[item.Category]++
+		err := s.branch(i+1, count+1, cost+item.Cost, value+item.Closeness)
+		s.counts[item.Category]--
+		s.chosen[i] = false
+		if err != nil {
+			return err
+		}
+	}
+	return s.branch(i+1, count, cost, value)
+}
+
+// upperBound adalah batas atas nilai yang masih bisa dicapai dari items[i:]:
+// nilai terkecil antara knapsack pecahan dan jumlah closeness terbesar
+// sebanyak sisa jumlah maksimum.
+func (s *portfolioSearch) upperBound(i, count int, cost, value float64) float64 {
+	budget := s.req.Budget - cost
+	fractional := value
+	for j := i; j < len(s.items); j++ {
+		item := s.items[j]
+		if item.Cost <= budget {
+			budget -= item.Cost
+			fractional += item.Closeness
+			continue
+		}
+		if budget > 0 {
+			fractional += item.Closeness * budget / item.Cost
+		}
+		break
+	}
+	if s.req.MaxCount == 0 {
+		return fractional
+	}
+	slots := s.req.MaxCount - count
+	top := value
+	for _, j := range s.byCloseness {
+		if slots == 0 {
+			break
+		}
+		if j >= i {
+			top += s.items[j].Closeness
+			slots--
+		}
+	}
+	return math.Min(fractional, top)
+}
@@ -0,0 +1,198 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const (
+	DefaultPreferenceSamples = 2000
+	MaxPreferenceSamples     = 50000
+	// langkah awal dan akhir pemindahan bobot antar kriteria pada pencarian lokal
+	preferenceInitialStep = 0.1
+	preferenceMinimumStep = 1e-4
+	// batas putaran pencarian lokal; setiap putaran mencoba semua pasangan
+	// kriteria, dan langkah hanya mengecil pada putaran tanpa perbaikan
+	MaxPreferencePasses = 1000
+)
+
+// preferenceScore dibandingkan secara leksikografis: jumlah preferensi yang
+// terpenuhi, lalu total pelanggaran (jumlah margin negatif), lalu margin
+// terkecil.
+type preferenceScore struct {
+	satisfied int
+	violation float64
+	minMargin float64
+}
+
+func (s preferenceScore) better(other preferenceScore) bool {
+	if s.satisfied != other.satisfied {
+		return s.satisfied > other.satisfied
+	}
+	if math.Abs(s.violation-other.violation) > 1e-12 {
+		return s.violation > other.violation
+	}
+	return s.minMargin > other.minMargin+1e-12
+}
+
+// LearnWeights mencari bobot yang membuat sebanyak mungkin pasangan "A di atas
+// B" terpenuhi pada pipeline TOPSIS yang sama. Closeness tidak linear
+// terhadap bobot, jadi pencarian memakai bobot saat ini, bobot sama rata,
+// sudut simplex dan sampel acak Dirichlet, lalu titik terbaik diperhalus
+// dengan memindahkan sebagian bobot dari satu kriteria ke kriteria lain
+// selama skor membaik, paling banyak MaxPreferencePasses putaran.
+func LearnWeights(req helperTopsis.PreferenceRequest) (helperTopsis.PreferenceResult, error) {
+	if len(req.Preferences) == 0 {
+		return helperTopsis.PreferenceResult{}, fmt.Errorf("No preferences Provided")
+	}
+	samples := req.Samples
+	if samples <= 0 {
+		samples = DefaultPreferenceSamples
+	}
+	if samples > MaxPreferenceSamples {
+		return helperTopsis.PreferenceResult{}, fmt.Errorf("samples must not exceed %d", MaxPreferenceSamples)
+	}
+	prepared, _, err := prepareRequest(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.PreferenceResult{}, err
+	}
+	// preferensi hanya bermakna untuk alternatif yang ikut diranking Topsis
+	removed := make(map[string]string)
+	var rejected []helperTopsis.RejectedAlternative
+	prepared.Alternatives, rejected = helperTopsis.CheckConstraints(prepared.Alternatives, prepared.Criteria)
+	for _, alt := range rejected {
+		removed[alt.Name] = "rejected by the constraints"
+	}
+	if prepared.Dominance != "" {
+		var dominance *helperTopsis.DominanceReport
+		prepared, dominance = applyDominance(prepared)
+		for _, name := range dominance.Excluded {
+			removed[name] = "excluded as dominated"
+		}
+	}
+	known := make(map[string]bool, len(prepared.Alternatives))
+	for _, alt := range prepared.Alternatives {
+		known[alt.Name] = true
+	}
+	for _, preference := range req.Preferences {
+		if preference.Better == preference.Worse {
+			return helperTopsis.PreferenceResult{}, fmt.Errorf("preference compares %s with itself", preference.Better)
+		}
+		for _, name := range []string{preference.Better, preference.Worse} {
+			if reason, ok := removed[name]; ok {
+				return helperTopsis.PreferenceResult{}, fmt.Errorf("Alternative %s was %s", name, reason)
+			}
+			if !known[name] {
+				return helperTopsis.PreferenceResult{}, fmt.Errorf("Alternative %s not found", name)
+			}
+		}
+	}
+
+	evaluator := newWeightEvaluator(prepared)
+	m := len(prepared.Criteria)
+	result := helperTopsis.PreferenceResult{Total: len(req.Preferences)}
+	score := func(weights []float64) preferenceScore {
+		result.SamplesEvaluated++
+		closeness := evaluator.closeness(weights)
+		s := preferenceScore{minMargin: math.Inf(1)}
+		for _, preference := range req.Preferences {
+			margin := closeness[preference.Better] - closeness[preference.Worse]
+			if margin > 0 {
+				s.satisfied++
+			} else {
+				s.violation += margin
+			}
+			s.minMargin = math.Min(s.minMargin, margin)
+		}
+		return s
+	}
+
+	current := make([]float64, m)
+	equal := make([]float64, m)
+	for i, criterion := range prepared.Criteria {
+		current[i] = criterion.Weight
+		equal[i] = 1 / float64(m)
+	}
+	candidates := [][]float64{current, equal}
+	for i := 0; i < m; i++ {
+		vertex := make([]float64, m)
+		vertex[i] = 1
+		candidates = append(candidates, vertex)
+	}
+	rng := rand.New(rand.NewSource(req.Seed))
+	for s := 0; s < samples; s++ {
+		weights := make([]float64, m)
+		sum := 0.0
+		for i := range weights {
+			weights[i] = rng.ExpFloat64()
+			sum += weights[i]
+		}
+		for i := range weights {
+			weights[i] /= sum
+		}
+		candidates = append(candidates, weights)
+	}
+
+	best := current
+	bestScore := score(current)
+	for _, weights := range candidates[1:] {
+		if s := score(weights); s.better(bestScore) {
+			best, bestScore = weights, s
+		}
+	}
+
+	for step, pass := preferenceInitialStep, 0; step >= preferenceMinimumStep && pass < MaxPreferencePasses; pass++ {
+		improved := false
+		for from := 0; from < m; from++ {
+			for to := 0; to < m; to++ {
+				if from == to || best[from] == 0 {
+					continue
+				}
+				moved := math.Min(step, best[from])
+				weights := copyWeights(best)
+				weights[from] -= moved
+				weights[to] += moved
+				if s := score(weights); s.better(bestScore) {
+					best, bestScore = weights, s
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	result.Weights = weightMap(prepared.Criteria, best)
+	result.Satisfied = bestScore.satisfied
+	result.WeightedCriteria = make([]helperTopsis.Criterion, m)
+	copy(result.WeightedCriteria, prepared.Criteria)
+	for i := range result.WeightedCriteria {
+		result.WeightedCriteria[i].Weight = best[i]
+	}
+	closeness := evaluator.closeness(best)
+	for _, preference := range req.Preferences {
+		outcome := helperTopsis.PreferenceOutcome{
+			Better:          preference.Better,
+			Worse:           preference.Worse,
+			ClosenessBetter: closeness[preference.Better],
+			ClosenessWorse:  closeness[preference.Worse],
+		}
+		outcome.Margin = outcome.ClosenessBetter - outcome.ClosenessWorse
+		outcome.Satisfied = outcome.Margin > 0
+		if !outcome.Satisfied {
+			result.Unsatisfied = append(result.Unsatisfied, preference)
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+	return result, nil
+}
+
+func copyWeights(weights []float64) []float64 {
+	copied := make([]float64, len(weights))
+	copy(copied, weights)
+	return copied
+}
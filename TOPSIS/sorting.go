@@ -0,0 +1,129 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// SortTopsis adalah TOPSIS-Sort: profil kelas ikut dihitung sebagai baris
+// matriks keputusan, sehingga normalisasi, solusi ideal dan jarak sama
+// dengan alternatif sungguhan. Pada mode boundary alternatif masuk kelas
+// terbaik yang batas bawahnya tidak melebihi closeness alternatif. Pada mode
+// characteristic alternatif masuk kelas dengan closeness profil terdekat,
+// jadi batas antar kelas adalah titik tengah dua profil yang berurutan.
+func SortTopsis(req helperTopsis.SortingRequest) (helperTopsis.SortingResponse, error) {
+	if err := helperTopsis.ValidateSortingRequest(req); err != nil {
+		return helperTopsis.SortingResponse{}, err
+	}
+	flat, err := applyCriteriaTree(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.SortingResponse{}, err
+	}
+	flat.CriteriaTree = nil
+
+	// profil harus lengkap, kebijakan nilai kosong hanya untuk alternatif
+	complete := make([]helperTopsis.Criterion, len(flat.Criteria))
+	copy(complete, flat.Criteria)
+	for i := range complete {
+		complete[i].MissingPolicy = ""
+	}
+	complete = helperTopsis.RankedCriteria(complete)
+	names := make(map[string]bool, len(flat.Alternatives))
+	for _, alt := range flat.Alternatives {
+		names[alt.Name] = true
+	}
+	profiles := helperTopsis.SortingProfiles(req)
+	for _, profile := range profiles {
+		if names[profile.Name] {
+			return helperTopsis.SortingResponse{}, fmt.Errorf("Alternative name %s is reserved for a profile", profile.Name)
+		}
+		if err := helperTopsis.ValidateAlternative(profile, complete); err != nil {
+			return helperTopsis.SortingResponse{}, err
+		}
+	}
+	// kebijakan nilai kosong, satuan dan skala hanya untuk alternatif
+	// sungguhan, profil sudah lengkap dan memakai satuan kriteria, jadi
+	// cukup dihitung kriteria turunannya
+	prepared, prep, err := prepareRequest(flat)
+	if err != nil {
+		return helperTopsis.SortingResponse{}, err
+	}
+	alternatives, rejected := helperTopsis.CheckConstraints(prepared.Alternatives, prepared.Criteria)
+	derivedProfiles, profileErrors := helperTopsis.DeriveCriteria(helperTopsis.TOPSISRequest{
+		Criteria:     prepared.Criteria,
+		Alternatives: profiles,
+	})
+	if len(profileErrors) > 0 {
+		return helperTopsis.SortingResponse{}, fmt.Errorf(
+			"profile %s: %s", profileErrors[0].Alternative, profileErrors[0].Error)
+	}
+	prepared.Alternatives = append(append([]helperTopsis.Alternative{}, alternatives...), derivedProfiles.Alternatives...)
+
+	calculated := calculate(prepared)
+	closeness := make(map[string]float64, len(calculated.Results))
+	for _, result := range calculated.Results {
+		closeness[result.Name] = result.ClosenessValue
+	}
+	response := helperTopsis.SortingResponse{
+		Mode:             req.Mode,
+		Assignments:      make([]helperTopsis.SortAssignment, 0, len(alternatives)),
+		Classes:          make([]helperTopsis.SortClassSummary, len(req.Classes)),
+		Profiles:         make([]helperTopsis.SortProfile, len(profiles)),
+		Rejected:         rejected,
+		Missing:          prep.missing,
+		ExpressionErrors: prep.expressionErrors,
+		Warnings:         calculated.Warnings,
+	}
+	for i, profile := range profiles {
+		response.Profiles[i] = helperTopsis.SortProfile{Name: profile.Name, Closeness: closeness[profile.Name]}
+		if i > 0 && response.Profiles[i].Closeness >= response.Profiles[i-1].Closeness {
+			return helperTopsis.SortingResponse{}, fmt.Errorf(
+				"profile %s is not worse than %s", profile.Name, profiles[i-1].Name)
+		}
+	}
+
+	// batas kelas dalam closeness: bounds[i] memisahkan kelas i dan i+1
+	bounds := make([]float64, len(req.Classes)-1)
+	for i := range bounds {
+		if req.Mode == helperTopsis.SortingBoundary {
+			bounds[i] = response.Profiles[i].Closeness
+		} else {
+			bounds[i] = (response.Profiles[i].Closeness + response.Profiles[i+1].Closeness) / 2
+		}
+	}
+	members := make([][]string, len(req.Classes))
+	for _, alt := range alternatives {
+		value := closeness[alt.Name]
+		class := len(bounds)
+		for i, bound := range bounds {
+			if value >= bound {
+				class = i
+				break
+			}
+		}
+		assignment := helperTopsis.SortAssignment{Name: alt.Name, Class: req.Classes[class].Name, Closeness: value, Margin: math.Inf(1)}
+		if class > 0 {
+			assignment.Margin = bounds[class-1] - value
+			assignment.NearestClass = req.Classes[class-1].Name
+		}
+		if class < len(bounds) && value-bounds[class] < assignment.Margin {
+			assignment.Margin = value - bounds[class]
+			assignment.NearestClass = req.Classes[class+1].Name
+		}
+		members[class] = append(members[class], alt.Name)
+		response.Assignments = append(response.Assignments, assignment)
+	}
+	sort.SliceStable(response.Assignments, func(i, j int) bool {
+		return response.Assignments[i].Closeness > response.Assignments[j].Closeness
+	})
+	for i, class := range req.Classes {
+		response.Classes[i] = helperTopsis.SortClassSummary{Name: class.Name, Members: members[i]}
+		if response.Classes[i].Members == nil {
+			response.Classes[i].Members = []string{}
+		}
+	}
+	return response, nil
+}
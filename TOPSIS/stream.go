@@ -0,0 +1,369 @@
+package topsis
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const DefaultStreamTopK = 10
+
+// TopsisStream menghitung TOPSIS dari input NDJSON: baris pertama adalah
+// TOPSISStreamHeader, baris berikutnya masing-masing satu Alternative.
+// Hanya top-k hasil yang disimpan di memori, sisanya diringkas di Rest.
+//
+// Jika header membawa Reference, alternatif cukup dibaca sekali. Jika tidak,
+// input di-spool ke file sementara: pass pertama menghitung faktor normalisasi
+// dan solusi ideal, pass kedua menghitung jarak dan closeness. Baris yang
+// melanggar batas Min/Max kriteria tidak diranking, sama seperti mode biasa,
+// dan hanya jumlahnya yang dicatat. Baris yang ekspresinya gagal dihitung
+// juga dilewati dan dicatat di ExpressionErrors.
+func TopsisStream(r io.Reader) (helperTopsis.TOPSISStreamResponse, error) {
+	dec := json.NewDecoder(r)
+	var header helperTopsis.TOPSISStreamHeader
+	if err := dec.Decode(&header); err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("invalid stream header: %w", err)
+	}
+	if err := helperTopsis.ValidateCriteria(header.Criteria); err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, err
+	}
+	for _, criterion := range header.Criteria {
+		// imputasi butuh statistik seluruh kolom, jadi mode streaming hanya menerima data lengkap
+		if criterion.MissingPolicy != "" && criterion.MissingPolicy != helperTopsis.MissingReject {
+			return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf(
+				"missing value policy %s for %s is not supported in stream mode",
+				criterion.MissingPolicy, criterion.Name)
+		}
+	}
+	// kolom bantu tetap dibaca per baris untuk ekspresi, tapi tidak diranking
+	ranked := helperTopsis.RankedCriteria(header.Criteria)
+	topK := header.TopK
+	if topK <= 0 {
+		topK = DefaultStreamTopK
+	}
+
+	if ref := header.Reference; ref != nil {
+		if err := validateStreamReference(ranked, ref); err != nil {
+			return helperTopsis.TOPSISStreamResponse{}, err
+		}
+		ranker := newStreamRanker(
+			ranked,
+			topK,
+			ref.NormalizationFactors,
+			ref.IdealPositive,
+			ref.IdealNegative,
+		)
+		counts, err := forEachStreamAlternative(dec, header.Criteria, ranker.add)
+		if err != nil {
+			return helperTopsis.TOPSISStreamResponse{}, err
+		}
+		if counts.rows == 0 {
+			return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("No Alternative Provided")
+		}
+		response := ranker.response(counts.accepted, 1)
+		response.Rejected = counts.rejected
+		response.ExpressionErrors = counts.expressionErrors
+		return response, nil
+	}
+
+	spool, err := os.CreateTemp("", "topsis-stream-*.ndjson")
+	if err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	// pass pertama: simpan alternatif ke spool sambil menghitung jumlah kuadrat
+	// serta nilai minimum dan maksimum mentah per kriteria
+	writer := bufio.NewWriter(spool)
+	enc := json.NewEncoder(writer)
+	sumOfSquares := make(map[string]float64)
+	minValues := make(map[string]float64)
+	maxValues := make(map[string]float64)
+	counts, err := forEachStreamAlternative(dec, header.Criteria, func(alt helperTopsis.Alternative) error {
+		for _, criterion := range ranked {
+			value := alt.Values[criterion.Name]
+			sumOfSquares[criterion.Name] += value * value
+			if current, ok := minValues[criterion.Name]; !ok || value < current {
+				minValues[criterion.Name] = value
+			}
+			if current, ok := maxValues[criterion.Name]; !ok || value > current {
+				maxValues[criterion.Name] = value
+			}
+		}
+		return enc.Encode(alt)
+	})
+	if err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, err
+	}
+	if counts.rows == 0 {
+		return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("No Alternative Provided")
+	}
+	if counts.accepted == 0 {
+		return helperTopsis.TOPSISStreamResponse{
+			Results:          []helperTopsis.TOPSISResult{},
+			Passes:           1,
+			Rejected:         counts.rejected,
+			ExpressionErrors: counts.expressionErrors,
+		}, nil
+	}
+	if err := writer.Flush(); err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("failed to write spool file: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, fmt.Errorf("failed to rewind spool file: %w", err)
+	}
+
+	// faktor normalisasi positif dan bobot tidak negatif menjaga urutan nilai,
+	// jadi solusi ideal bisa diturunkan langsung dari nilai min/max mentah
+	normFactors := make(map[string]float64)
+	idealPositive := make(map[string]float64)
+	idealNegative := make(map[string]float64)
+	for _, criterion := range ranked {
+		factor := math.Sqrt(sumOfSquares[criterion.Name])
+		normFactors[criterion.Name] = factor
+		low, high := 0.0, 0.0
+		if factor > 0 {
+			low = minValues[criterion.Name] / factor * criterion.Weight
+			high = maxValues[criterion.Name] / factor * criterion.Weight
+		}
+		if criterion.Type == helperTopsis.Benefit {
+			idealPositive[criterion.Name], idealNegative[criterion.Name] = high, low
+		} else {
+			idealPositive[criterion.Name], idealNegative[criterion.Name] = low, high
+		}
+	}
+
+	ranker := newStreamRanker(ranked, topK, normFactors, idealPositive, idealNegative)
+	if _, err := forEachStreamAlternative(
+		json.NewDecoder(bufio.NewReader(spool)),
+		ranked,
+		ranker.add,
+	); err != nil {
+		return helperTopsis.TOPSISStreamResponse{}, err
+	}
+	response := ranker.response(counts.accepted, 2)
+	response.Rejected = counts.rejected
+	response.ExpressionErrors = counts.expressionErrors
+	response.Warnings = helperTopsis.VectorNormalizationWarnings(ranked, normFactors, minValues, maxValues)
+	return response, nil
+}
+
+func validateStreamReference(
+	criteria []helperTopsis.Criterion,
+	ref *helperTopsis.StreamReference,
+) error {
+	for _, criterion := range criteria {
+		if _, ok := ref.NormalizationFactors[criterion.Name]; !ok {
+			return fmt.Errorf("reference is missing normalization factor for criteria %s", criterion.Name)
+		}
+		if _, ok := ref.IdealPositive[criterion.Name]; !ok {
+			return fmt.Errorf("reference is missing ideal positive for criteria %s", criterion.Name)
+		}
+		if _, ok := ref.IdealNegative[criterion.Name]; !ok {
+			return fmt.Errorf("reference is missing ideal negative for criteria %s", criterion.Name)
+		}
+	}
+	return nil
+}
+
+// streamCounts meringkas baris yang dibaca forEachStreamAlternative. rows
+// menghitung setiap baris alternatif, termasuk yang ditolak atau gagal
+// dihitung ekspresinya, dan dipakai sebagai nomor baris pada pesan error.
+type streamCounts struct {
+	rows             int
+	accepted         int
+	rejected         int
+	expressionErrors []helperTopsis.ExpressionError
+}
+
+// forEachStreamAlternative memanggil fn untuk setiap baris yang valid,
+// ekspresinya berhasil dihitung, dan memenuhi batas kriteria.
+func forEachStreamAlternative(
+	dec *json.Decoder,
+	criteria []helperTopsis.Criterion,
+	fn func(helperTopsis.Alternative) error,
+) (streamCounts, error) {
+	ranked := helperTopsis.RankedCriteria(criteria)
+	var counts streamCounts
+	for {
+		var alt helperTopsis.Alternative
+		err := dec.Decode(&alt)
+		if err == io.EOF {
+			return counts, nil
+		}
+		counts.rows++
+		if err != nil {
+			return counts, fmt.Errorf("invalid alternative at row %d: %w", counts.rows, err)
+		}
+		if err := helperTopsis.ValidateAlternative(alt, criteria); err != nil {
+			return counts, fmt.Errorf("row %d: %w", counts.rows, err)
+		}
+		single, _, err := helperTopsis.ConvertUnits(helperTopsis.TOPSISRequest{
+			Criteria:     criteria,
+			Alternatives: []helperTopsis.Alternative{alt},
+		}, unitRegistry)
+		if err != nil {
+			return counts, fmt.Errorf("row %d: %w", counts.rows, err)
+		}
+		single = helperTopsis.ApplyScales(single)
+		single, errs := helperTopsis.DeriveCriteria(single)
+		if len(errs) > 0 {
+			counts.expressionErrors = append(counts.expressionErrors, errs...)
+			continue
+		}
+		accepted, violations := helperTopsis.CheckConstraints(single.Alternatives, ranked)
+		if len(violations) > 0 {
+			counts.rejected++
+			continue
+		}
+		alt = accepted[0]
+		if err := fn(alt); err != nil {
+			return counts, err
+		}
+		counts.accepted++
+	}
+}
+
+// streamRanker menyimpan top-k hasil dalam min-heap berdasarkan closeness,
+// alternatif yang tergeser dari heap dicatat ke ringkasan sisa.
+type streamRanker struct {
+	criteria      []helperTopsis.Criterion
+	topK          int
+	normFactors   map[string]float64
+	idealPositive map[string]float64
+	idealNegative map[string]float64
+	top           closenessHeap
+	rest          closenessAccumulator
+}
+
+func newStreamRanker(
+	criteria []helperTopsis.Criterion,
+	topK int,
+	normFactors, idealPositive, idealNegative map[string]float64,
+) *streamRanker {
+	return &streamRanker{
+		criteria:      criteria,
+		topK:          topK,
+		normFactors:   normFactors,
+		idealPositive: idealPositive,
+		idealNegative: idealNegative,
+		top:           make(closenessHeap, 0, topK),
+	}
+}
+
+func (s *streamRanker) add(alt helperTopsis.Alternative) error {
+	req := helperTopsis.TOPSISRequest{
+		Criteria:     s.criteria,
+		Alternatives: []helperTopsis.Alternative{alt},
+	}
+	normalizedMatrix := helperTopsis.NormalizeDecisionatrix(req, s.normFactors)
+	weightedMatrix := helperTopsis.CalculateWeightedNormalizedMatrix(normalizedMatrix, s.criteria)
+	positiveDistances, negativeDistances := helperTopsis.CalculateSeparationMeasures(
+		weightedMatrix,
+		s.idealPositive,
+		s.idealNegative,
+	)
+	result := helperTopsis.CalculateClosenessAndRank(
+		req.Alternatives,
+		positiveDistances,
+		negativeDistances,
+		normalizedMatrix,
+		weightedMatrix,
+	)[0]
+
+	if len(s.top) < s.topK {
+		heap.Push(&s.top, result)
+		return nil
+	}
+	if result.ClosenessValue > s.top[0].ClosenessValue {
+		s.rest.add(s.top[0].ClosenessValue)
+		s.top[0] = result
+		heap.Fix(&s.top, 0)
+		return nil
+	}
+	s.rest.add(result.ClosenessValue)
+	return nil
+}
+
+func (s *streamRanker) response(total, passes int) helperTopsis.TOPSISStreamResponse {
+	results := make([]helperTopsis.TOPSISResult, len(s.top))
+	copy(results, s.top)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].ClosenessValue > results[j].ClosenessValue
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+	return helperTopsis.TOPSISStreamResponse{
+		Results:              results,
+		Rest:                 s.rest.summary(),
+		TotalAlternatives:    total,
+		Passes:               passes,
+		IdealPositive:        s.idealPositive,
+		IdealNegative:        s.idealNegative,
+		NormalizationFactors: s.normFactors,
+	}
+}
+
+type closenessHeap []helperTopsis.TOPSISResult
+
+func (h closenessHeap) Len() int           { return len(h) }
+func (h closenessHeap) Less(i, j int) bool { return h[i].ClosenessValue < h[j].ClosenessValue }
+func (h closenessHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *closenessHeap) Push(x any) {
+	*h = append(*h, x.(helperTopsis.TOPSISResult))
+}
+
+func (h *closenessHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+type closenessAccumulator struct {
+	count        int
+	sum          float64
+	sumOfSquares float64
+	min          float64
+	max          float64
+}
+
+func (a *closenessAccumulator) add(value float64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+	if a.count == 0 || value > a.max {
+		a.max = value
+	}
+	a.count++
+	a.sum += value
+	a.sumOfSquares += value * value
+}
+
+func (a closenessAccumulator) summary() helperTopsis.ClosenessSummary {
+	if a.count == 0 {
+		return helperTopsis.ClosenessSummary{}
+	}
+	mean := a.sum / float64(a.count)
+	variance := a.sumOfSquares/float64(a.count) - mean*mean
+	if variance < 0 {
+		variance = 0
+	}
+	return helperTopsis.ClosenessSummary{
+		Count:  a.count,
+		Mean:   mean,
+		StdDev: math.Sqrt(variance),
+		Min:    a.min,
+		Max:    a.max,
+	}
+}
@@ -0,0 +1,156 @@
+package topsis
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+func streamTestRequest() helperTopsis.TOPSISRequest {
+	return helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "IPK", Weight: 0.5, Type: helperTopsis.Benefit},
+			{Name: "Skill", Weight: 0.3, Type: helperTopsis.Benefit},
+			{Name: "TransportCost", Weight: 0.2, Type: helperTopsis.Cost},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"IPK": 3.5, "Skill": 80, "TransportCost": 20}},
+			{Name: "B", Values: map[string]float64{"IPK": 3.2, "Skill": 90, "TransportCost": 15}},
+			{Name: "C", Values: map[string]float64{"IPK": 3.8, "Skill": 85, "TransportCost": 30}},
+			{Name: "D", Values: map[string]float64{"IPK": 2.9, "Skill": 70, "TransportCost": 10}},
+			{Name: "E", Values: map[string]float64{"IPK": 3.6, "Skill": 95, "TransportCost": 25}},
+		},
+	}
+}
+
+func encodeStream(t *testing.T, header helperTopsis.TOPSISStreamHeader, alts []helperTopsis.Alternative) *bytes.Buffer {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	require.NoError(t, enc.Encode(header))
+	for _, alt := range alts {
+		require.NoError(t, enc.Encode(alt))
+	}
+	return &buf
+}
+
+func TestTopsisStream(t *testing.T) {
+	req := streamTestRequest()
+	full, err := Topsis(req)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		reference *helperTopsis.StreamReference
+		passes    int
+	}{
+		{name: "two pass", passes: 2},
+		{
+			name: "single pass with reference",
+			reference: &helperTopsis.StreamReference{
+				IdealPositive:        full.IdealPositive,
+				IdealNegative:        full.IdealNegative,
+				NormalizationFactors: full.NormalizationFactors,
+			},
+			passes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := helperTopsis.TOPSISStreamHeader{Criteria: req.Criteria, TopK: 2, Reference: tt.reference}
+			response, err := TopsisStream(encodeStream(t, header, req.Alternatives))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.passes, response.Passes)
+			assert.Equal(t, len(req.Alternatives), response.TotalAlternatives)
+			require.Len(t, response.Results, 2)
+			for i, result := range response.Results {
+				assert.Equal(t, full.Results[i].Name, result.Name)
+				assert.Equal(t, i+1, result.Rank)
+				assert.InDelta(t, full.Results[i].ClosenessValue, result.ClosenessValue, 1e-9)
+			}
+
+			assert.Equal(t, 3, response.Rest.Count)
+			assert.InDelta(t, full.Results[2].ClosenessValue, response.Rest.Max, 1e-9)
+			assert.InDelta(t, full.Results[4].ClosenessValue, response.Rest.Min, 1e-9)
+		})
+	}
+}
+
+func TestTopsisStreamInvalidRow(t *testing.T) {
+	req := streamTestRequest()
+	alts := append(req.Alternatives, helperTopsis.Alternative{Name: "F", Values: map[string]float64{"IPK": 3}})
+
+	_, err := TopsisStream(encodeStream(t, helperTopsis.TOPSISStreamHeader{Criteria: req.Criteria}, alts))
+	assert.ErrorContains(t, err, "row 6")
+}
+
+func TestTopsisStreamConstraintsAndWarnings(t *testing.T) {
+	req := streamTestRequest()
+	minIPK := 3.0
+	req.Criteria[0].Min = &minIPK
+	for i := range req.Alternatives {
+		req.Alternatives[i].Values["Skill"] = 80
+	}
+	full, err := Topsis(req)
+	require.NoError(t, err)
+	require.Len(t, full.Rejected, 1)
+
+	header := helperTopsis.TOPSISStreamHeader{Criteria: req.Criteria, TopK: 10}
+	response, err := TopsisStream(encodeStream(t, header, req.Alternatives))
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Rejected)
+	assert.Equal(t, len(full.Results), response.TotalAlternatives)
+	require.Len(t, response.Results, len(full.Results))
+	for i, result := range response.Results {
+		assert.Equal(t, full.Results[i].Name, result.Name)
+		assert.InDelta(t, full.Results[i].ClosenessValue, result.ClosenessValue, 1e-9)
+	}
+	assert.Equal(t, warningCodes(full.Warnings), warningCodes(response.Warnings))
+	assert.Contains(t, warningCodes(response.Warnings), helperTopsis.WarningZeroVariance)
+}
+
+func TestTopsisStreamExpressionErrorsAndRowNumbers(t *testing.T) {
+	req := streamTestRequest()
+	req.Criteria = append(req.Criteria[:2], helperTopsis.Criterion{
+		Name: "TransportCost", Weight: 0.2, Type: helperTopsis.Cost, Expression: "Biaya / Jarak",
+	})
+	for i := range req.Alternatives {
+		req.Alternatives[i].Values["Biaya"] = req.Alternatives[i].Values["TransportCost"] * 2
+		req.Alternatives[i].Values["Jarak"] = 2
+		delete(req.Alternatives[i].Values, "TransportCost")
+	}
+	req.Alternatives[1].Values["Jarak"] = 0
+	full, err := Topsis(req)
+	require.NoError(t, err)
+	require.Len(t, full.ExpressionErrors, 1)
+
+	for _, reference := range []*helperTopsis.StreamReference{nil, {
+		NormalizationFactors: full.NormalizationFactors,
+		IdealPositive:        full.IdealPositive,
+		IdealNegative:        full.IdealNegative,
+	}} {
+		header := helperTopsis.TOPSISStreamHeader{Criteria: req.Criteria, TopK: 10, Reference: reference}
+		response, err := TopsisStream(encodeStream(t, header, req.Alternatives))
+		require.NoError(t, err)
+		assert.Equal(t, full.ExpressionErrors, response.ExpressionErrors)
+		require.Len(t, response.Results, len(full.Results))
+		for i, result := range response.Results {
+			assert.Equal(t, full.Results[i].Name, result.Name)
+			assert.InDelta(t, full.Results[i].ClosenessValue, result.ClosenessValue, 1e-9)
+		}
+	}
+
+	// nomor baris tetap menghitung baris yang ditolak batas kriteria
+	minIPK := 3.0
+	req.Criteria[0].Min = &minIPK
+	alts := append([]helperTopsis.Alternative{}, req.Alternatives[3], req.Alternatives[0],
+		helperTopsis.Alternative{Name: "F", Values: map[string]float64{"Skill": 70, "Biaya": 10, "Jarak": 1}})
+	_, err = TopsisStream(encodeStream(t, helperTopsis.TOPSISStreamHeader{Criteria: req.Criteria}, alts))
+	assert.ErrorContains(t, err, "row 3:")
+}
@@ -1,22 +1,120 @@
 package topsis
 
 import (
+	"fmt"
+
 	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
 )
 
 func Topsis(req helperTopsis.TOPSISRequest) (helperTopsis.TOPSISResponse, error) {
-	if err := helperTopsis.ValidateInput(req); err != nil {
+	req, prep, err := prepareRequest(req)
+	if err != nil {
 		return helperTopsis.TOPSISResponse{}, err
 	}
+	return rankPrepared(req, prep)
+}
+
+// rankPrepared menjalankan sisa Topsis atas hasil prepareRequest, dipakai
+// oleh analisis yang juga butuh matriks hasil pra-proses.
+func rankPrepared(req helperTopsis.TOPSISRequest, prep preparation) (helperTopsis.TOPSISResponse, error) {
+	var err error
+	var rejected []helperTopsis.RejectedAlternative
+	req.Alternatives, rejected = helperTopsis.CheckConstraints(req.Alternatives, req.Criteria)
+
+	var dominance *helperTopsis.DominanceReport
+	if req.Dominance != "" {
+		req, dominance = applyDominance(req)
+	}
+
+	response := calculate(req)
+	if req.Dominance == helperTopsis.DominanceMark {
+		markDominance(response.Results, dominance)
+	}
+	helperTopsis.LabelResults(response.Results, req.Alternatives, req.Criteria)
+	helperTopsis.UnitResults(response.Results, prep.conversions)
+	if req.Clustering != nil {
+		var clusteringWarnings []helperTopsis.CalculationWarning
+		response.Clustering, clusteringWarnings, err = helperTopsis.ClusterResults(
+			response.Results, req.Criteria, *req.Clustering)
+		if err != nil {
+			return helperTopsis.TOPSISResponse{}, err
+		}
+		response.Warnings = append(response.Warnings, clusteringWarnings...)
+	}
+	response.Dominance = dominance
+	response.Rejected = rejected
+	response.Missing = prep.missing
+	response.ExpressionErrors = prep.expressionErrors
+	response.Weighting = prep.weighting
+	if len(req.CriteriaTree) > 0 {
+		response.Branches = branchResults(req, response)
+	}
+	return response, nil
+}
+
+// preparation mencatat apa yang terjadi pada input selama prepareRequest.
+type preparation struct {
+	weighting        *helperTopsis.WeightingResult
+	conversions      []helperTopsis.UnitConversion
+	missing          *helperTopsis.MissingValueReport
+	expressionErrors []helperTopsis.ExpressionError
+}
+
+// prepareRequest memvalidasi request lalu mengubah semua input menjadi matriks
+// angka lengkap: pohon kriteria diratakan, bobot dibangkitkan dari urutan
+// kepentingan, nilai dikonversi ke satuan kanonik, label skala diubah ke
+// angka, nilai kosong diisi sesuai kebijakannya, kriteria turunan dihitung,
+// dan kolom bantu dikeluarkan dari daftar kriteria.
+func prepareRequest(req helperTopsis.TOPSISRequest) (helperTopsis.TOPSISRequest, preparation, error) {
+	var prep preparation
+	req, err := applyCriteriaTree(req)
+	if err != nil {
+		return req, prep, err
+	}
+	if req.Weighting != nil && len(req.CriteriaTree) > 0 {
+		return req, prep, fmt.Errorf("weighting cannot be combined with criteriaTree")
+	}
+	req, prep.weighting, err = helperTopsis.ApplyWeighting(req)
+	if err != nil {
+		return req, prep, err
+	}
+	if err := helperTopsis.ValidateInput(req); err != nil {
+		return req, prep, err
+	}
+	req, prep.conversions, err = helperTopsis.ConvertUnits(req, unitRegistry)
+	if err != nil {
+		return req, prep, err
+	}
+	req = helperTopsis.ApplyScales(req)
+	req, prep.missing, err = helperTopsis.ImputeMissingValues(req)
+	if err != nil {
+		return req, prep, err
+	}
+	if len(req.Alternatives) == 0 {
+		return req, prep, fmt.Errorf("No Alternative left after applying missing value policy %s", helperTopsis.MissingExclude)
+	}
+	req, prep.expressionErrors = helperTopsis.DeriveCriteria(req)
+	if len(req.Alternatives) == 0 {
+		return req, prep, fmt.Errorf("No Alternative left after evaluating expressions")
+	}
+	req.Criteria = helperTopsis.RankedCriteria(req.Criteria)
+	return req, prep, nil
+}
 
-	normFaktors := helperTopsis.CalculateNormalizationFactors(req)
-	normalizedMatrix := helperTopsis.NormalizeDecisionatrix(req, normFaktors)
+// calculate menjalankan langkah inti TOPSIS tanpa validasi maupun pra-proses,
+// dipakai ulang oleh analisis yang perlu menghitung ulang berkali-kali.
+func calculate(req helperTopsis.TOPSISRequest) helperTopsis.TOPSISResponse {
+	normFaktors, normalizedMatrix, warnings := helperTopsis.Normalize(req)
 	weightedMatrix := helperTopsis.CalculateWeightedNormalizedMatrix(normalizedMatrix, req.Criteria)
 	idealPositive, idealNegative := helperTopsis.DetermineIdealSolutions(
 		weightedMatrix,
 		req.Criteria,
 	)
-	positiveDistances, negativeDistances := helperTopsis.CalculateSeparationMeasures(
+	inverse, covarianceWarnings := inverseCovariance(req, normalizedMatrix)
+	warnings = append(warnings, covarianceWarnings...)
+	positiveDistances, negativeDistances := separationMeasures(
+		req.Criteria,
+		inverse,
 		weightedMatrix,
 		idealPositive,
 		idealNegative,
@@ -33,5 +131,6 @@ func Topsis(req helperTopsis.TOPSISRequest) (helperTopsis.TOPSISResponse, error)
 		IdealPositive:        idealPositive,
 		IdealNegative:        idealNegative,
 		NormalizationFactors: normFaktors,
-	}, nil
+		Warnings:             warnings,
+	}
 }
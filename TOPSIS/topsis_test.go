@@ -0,0 +1,1476 @@
+package topsis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+func dominanceTestRequest(mode string) helperTopsis.TOPSISRequest {
+	return helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Skill", Weight: 0.6, Type: helperTopsis.Benefit},
+			{Name: "Cost", Weight: 0.4, Type: helperTopsis.Cost},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"Skill": 90, "Cost": 10}},
+			{Name: "B", Values: map[string]float64{"Skill": 80, "Cost": 20}},
+			{Name: "C", Values: map[string]float64{"Skill": 95, "Cost": 30}},
+			{Name: "D", Values: map[string]float64{"Skill": 80, "Cost": 30}},
+		},
+		Dominance: mode,
+	}
+}
+
+func TestTopsisDominance(t *testing.T) {
+	t.Run("mark", func(t *testing.T) {
+		response, err := Topsis(dominanceTestRequest(helperTopsis.DominanceMark))
+		require.NoError(t, err)
+		require.NotNil(t, response.Dominance)
+
+		assert.Equal(t, []string{"A", "C"}, response.Dominance.ParetoSet)
+		assert.Empty(t, response.Dominance.Excluded)
+		assert.Len(t, response.Results, 4)
+		dominatedBy := make(map[string][]string)
+		for _, result := range response.Results {
+			dominatedBy[result.Name] = result.DominatedBy
+		}
+		assert.Empty(t, dominatedBy["A"])
+		assert.Equal(t, []string{"A"}, dominatedBy["B"])
+		assert.Equal(t, []string{"A", "B", "C"}, dominatedBy["D"])
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		response, err := Topsis(dominanceTestRequest(helperTopsis.DominanceExclude))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"B", "D"}, response.Dominance.Excluded)
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, "A", response.Results[0].Name)
+		assert.Empty(t, response.Results[0].DominatedBy)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := Topsis(dominanceTestRequest("drop"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTopsisConstraints(t *testing.T) {
+	minWawancara := 3.0
+	maxCost := 25.0
+	req := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Wawancara", Weight: 0.7, Type: helperTopsis.Benefit, Min: &minWawancara},
+			{Name: "Cost", Weight: 0.3, Type: helperTopsis.Cost, Max: &maxCost},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"Wawancara": 4, "Cost": 20}},
+			{Name: "B", Values: map[string]float64{"Wawancara": 2, "Cost": 5}},
+			{Name: "C", Values: map[string]float64{"Wawancara": 1, "Cost": 30}},
+			{Name: "D", Values: map[string]float64{"Wawancara": 3, "Cost": 10}},
+		},
+	}
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+
+	require.Len(t, response.Results, 2)
+	assert.ElementsMatch(t, []string{"A", "D"}, []string{response.Results[0].Name, response.Results[1].Name})
+	require.Len(t, response.Rejected, 2)
+	assert.Equal(t, "B", response.Rejected[0].Name)
+	assert.Equal(t, []helperTopsis.ConstraintViolation{
+		{Criterion: "Wawancara", Constraint: "min", Limit: 3, Value: 2},
+	}, response.Rejected[0].Violations)
+	assert.Equal(t, "C", response.Rejected[1].Name)
+	assert.Len(t, response.Rejected[1].Violations, 2)
+}
+
+func TestExplainPair(t *testing.T) {
+	req := helperTopsis.ExplainRequest{
+		TOPSISRequest: streamTestRequest(),
+		AlternativeA:  "E",
+		AlternativeB:  "D",
+		Language:      helperTopsis.LanguageEnglish,
+	}
+
+	explanation, err := ExplainPair(req)
+	require.NoError(t, err)
+
+	positiveSum, negativeSum := 0.0, 0.0
+	for _, contribution := range explanation.Contributions {
+		positiveSum += contribution.PositiveDistanceContribution
+		negativeSum += contribution.NegativeDistanceContribution
+	}
+	assert.InDelta(t, explanation.PositiveDistanceDiff, positiveSum, 1e-9)
+	assert.InDelta(t, explanation.NegativeDistanceDiff, negativeSum, 1e-9)
+	for i := 1; i < len(explanation.Contributions); i++ {
+		assert.GreaterOrEqual(t,
+			math.Abs(explanation.Contributions[i-1].Impact),
+			math.Abs(explanation.Contributions[i].Impact))
+	}
+	assert.Contains(t, explanation.Summary, "E ranks below D")
+
+	req.Language = ""
+	explanation, err = ExplainPair(req)
+	require.NoError(t, err)
+	assert.Contains(t, explanation.Summary, "E berada di bawah D")
+
+	req.AlternativeB = "Z"
+	_, err = ExplainPair(req)
+	assert.Error(t, err)
+}
+
+func TestSuggestImprovement(t *testing.T) {
+	req := helperTopsis.ImprovementRequest{
+		TOPSISRequest: streamTestRequest(),
+		Alternative:   "D",
+		TargetRank:    1,
+		Fixed:         []string{"TransportCost"},
+	}
+
+	suggestion, err := SuggestImprovement(req)
+	require.NoError(t, err)
+
+	assert.True(t, suggestion.Achieved)
+	assert.Greater(t, suggestion.OriginalRank, 1)
+	assert.Equal(t, 1, suggestion.ResultingRank)
+	assert.Greater(t, suggestion.ResultingCloseness, suggestion.OriginalCloseness)
+	assert.Equal(t, 10.0, suggestion.Values["TransportCost"])
+	for _, change := range suggestion.Changes {
+		assert.NotEqual(t, "TransportCost", change.Criterion)
+		assert.Greater(t, change.Delta, 0.0)
+	}
+
+	// hasil saran harus benar-benar menghasilkan peringkat target
+	improved := streamTestRequest()
+	for i := range improved.Alternatives {
+		if improved.Alternatives[i].Name == "D" {
+			improved.Alternatives[i].Values = suggestion.Values
+		}
+	}
+	response, err := Topsis(improved)
+	require.NoError(t, err)
+	assert.Equal(t, "D", response.Results[0].Name)
+
+	req.Fixed = []string{"IPK", "Skill", "TransportCost"}
+	suggestion, err = SuggestImprovement(req)
+	require.NoError(t, err)
+	assert.False(t, suggestion.Achieved)
+	assert.Empty(t, suggestion.Changes)
+}
+
+func TestSuggestImprovementDerivedAndClamped(t *testing.T) {
+	// IPK D (2.9) di atas batas atas; nilai awal tidak boleh dijepit lalu
+	// dilaporkan sebagai perubahan
+	maxIPK := 2.5
+	req := helperTopsis.ImprovementRequest{
+		TOPSISRequest: streamTestRequest(),
+		Alternative:   "D",
+		TargetRank:    1,
+		Fixed:         []string{"TransportCost"},
+		Bounds:        map[string]helperTopsis.ValueBounds{"IPK": {Max: &maxIPK}},
+	}
+	req.Alternatives = append(req.Alternatives,
+		helperTopsis.Alternative{Name: "F", Values: map[string]float64{"IPK": 2.0, "Skill": 60, "TransportCost": 40}})
+	req.Criteria = []helperTopsis.Criterion{
+		{Name: "IPK", Weight: 0.3, Type: helperTopsis.Benefit},
+		{Name: "Skill", Weight: 0.3, Type: helperTopsis.Benefit},
+		{Name: "TransportCost", Weight: 0.2, Type: helperTopsis.Cost},
+		{Name: "SkillPerCost", Weight: 0.2, Type: helperTopsis.Benefit, Expression: "Skill / TransportCost"},
+	}
+	suggestion, err := SuggestImprovement(req)
+	require.NoError(t, err)
+	require.True(t, suggestion.Achieved)
+	require.NotEmpty(t, suggestion.Changes)
+	for _, change := range suggestion.Changes {
+		assert.Equal(t, "Skill", change.Criterion)
+	}
+	assert.Equal(t, 2.9, suggestion.Values["IPK"])
+	assert.InDelta(t, suggestion.Values["Skill"]/10, suggestion.Values["SkillPerCost"], 1e-9)
+}
+
+func TestSuggestImprovementBoundsWithinCriterionLimits(t *testing.T) {
+	// Bounds yang lebih lebar dari Max kriteria tidak boleh melonggarkannya
+	maxSkill, wideSkill := 92.0, 200.0
+	req := helperTopsis.ImprovementRequest{
+		TOPSISRequest: streamTestRequest(),
+		Alternative:   "D",
+		TargetRank:    1,
+		Fixed:         []string{"IPK", "TransportCost"},
+		Bounds:        map[string]helperTopsis.ValueBounds{"Skill": {Max: &wideSkill}},
+	}
+	req.Criteria[1].Max = &maxSkill
+	suggestion, err := SuggestImprovement(req)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, suggestion.Values["Skill"], maxSkill)
+	assert.False(t, suggestion.Achieved)
+
+	improved := streamTestRequest()
+	improved.Criteria[1].Max = &maxSkill
+	for i := range improved.Alternatives {
+		if improved.Alternatives[i].Name == "D" {
+			improved.Alternatives[i].Values = suggestion.Values
+		}
+	}
+	response, err := Topsis(improved)
+	require.NoError(t, err)
+	for _, rejected := range response.Rejected {
+		assert.NotEqual(t, "D", rejected.Name)
+	}
+}
+
+func TestInverseWeights(t *testing.T) {
+	req := helperTopsis.InverseWeightRequest{
+		TOPSISRequest: streamTestRequest(),
+		Alternative:   "D",
+		Samples:       2000,
+	}
+
+	result, err := InverseWeights(req)
+	require.NoError(t, err)
+
+	require.True(t, result.Feasible)
+	assert.Greater(t, result.CurrentRank, 1)
+	assert.Greater(t, result.Distance, 0.0)
+	sum := 0.0
+	for name, weight := range result.ClosestWeights {
+		sum += weight
+		bounds := result.WeightBounds[name]
+		assert.GreaterOrEqual(t, weight, bounds.Min)
+		assert.LessOrEqual(t, weight, bounds.Max)
+	}
+	assert.InDelta(t, 1.0, sum, 1e-9)
+
+	// D punya biaya transport terendah, jadi bobot yang memenangkannya condong ke cost
+	reweighted := streamTestRequest()
+	for i := range reweighted.Criteria {
+		reweighted.Criteria[i].Weight = result.ClosestWeights[reweighted.Criteria[i].Name]
+	}
+	response, err := Topsis(reweighted)
+	require.NoError(t, err)
+	assert.Equal(t, "D", response.Results[0].Name)
+	assert.Greater(t, result.ClosestWeights["TransportCost"], 0.2)
+}
+
+func TestUncertainty(t *testing.T) {
+	body := `{
+		"criteria": [
+			{"name": "Wawancara", "weight": 0.6, "type": "benefit"},
+			{"name": "Tes", "weight": 0.4, "type": "benefit"}
+		],
+		"alternatives": [
+			{"name": "A", "values": {"Wawancara": {"distribution": "normal", "mean": 80, "sd": 5}, "Tes": 70}},
+			{"name": "B", "values": {"Wawancara": {"distribution": "uniform", "min": 60, "max": 70}, "Tes": 72}},
+			{"name": "C", "values": {"Wawancara": {"distribution": "triangular", "min": 50, "mode": 55, "max": 75}, "Tes": 90}}
+		],
+		"iterations": 500,
+		"seed": 7
+	}`
+	var req helperTopsis.UncertaintyRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+	assert.Equal(t, 80.0, req.Alternatives[0].Values["Wawancara"])
+	assert.Equal(t, 65.0, req.Alternatives[1].Values["Wawancara"])
+
+	result, err := Uncertainty(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 500, result.Iterations)
+	require.Len(t, result.Alternatives, 3)
+	for _, alt := range result.Alternatives {
+		assert.LessOrEqual(t, alt.ClosenessLower, alt.MeanCloseness)
+		assert.GreaterOrEqual(t, alt.ClosenessUpper, alt.MeanCloseness)
+		sum := 0.0
+		for _, p := range alt.RankProbabilities {
+			sum += p
+		}
+		assert.InDelta(t, 1.0, sum, 1e-9)
+	}
+	assert.InDelta(t, 1.0, result.BeatsProbability["A"]["B"]+result.BeatsProbability["B"]["A"], 1e-9)
+
+	again, err := Uncertainty(req)
+	require.NoError(t, err)
+	assert.Equal(t, result, again)
+
+	req.Alternatives[0].Distributions["Wawancara"] = helperTopsis.ValueDistribution{Distribution: "poisson"}
+	_, err = Uncertainty(req)
+	assert.Error(t, err)
+}
+
+func TestDynamicTopsis(t *testing.T) {
+	criteria := []helperTopsis.Criterion{
+		{Name: "Quality", Weight: 0.5, Type: helperTopsis.Benefit},
+		{Name: "Price", Weight: 0.5, Type: helperTopsis.Cost},
+	}
+	req := helperTopsis.DynamicTOPSISRequest{
+		TOPSISRequest: helperTopsis.TOPSISRequest{Criteria: criteria},
+		Periods: []helperTopsis.TOPSISPeriod{
+			{Label: "Q1", Alternatives: []helperTopsis.Alternative{
+				{Name: "S1", Values: map[string]float64{"Quality": 90, "Price": 10}},
+				{Name: "S2", Values: map[string]float64{"Quality": 60, "Price": 20}},
+			}},
+			{Label: "Q2", Alternatives: []helperTopsis.Alternative{
+				{Name: "S2", Values: map[string]float64{"Quality": 90, "Price": 10}},
+				{Name: "S1", Values: map[string]float64{"Quality": 60, "Price": 20}},
+			}},
+		},
+		TimeWeighting: helperTopsis.TimeWeightingExponential,
+		DecayRate:     0.25,
+	}
+
+	response, err := DynamicTopsis(req)
+	require.NoError(t, err)
+
+	require.Len(t, response.Periods, 2)
+	assert.InDelta(t, 0.2, response.Periods[0].Weight, 1e-9)
+	assert.InDelta(t, 0.8, response.Periods[1].Weight, 1e-9)
+	assert.Equal(t, "S2", response.Aggregate.Results[0].Name)
+	require.Len(t, response.Trajectories, 2)
+	assert.Equal(t, "S2", response.Trajectories[0].Name)
+	assert.Equal(t, []int{2, 1}, response.Trajectories[0].Ranks)
+
+	// opsi TOPSIS berlaku untuk setiap periode
+	req.Normalization = helperTopsis.NormalizationMinMax
+	req.Weighting = &helperTopsis.Weighting{Method: helperTopsis.WeightingROC, Order: []string{"Price", "Quality"}}
+	response, err = DynamicTopsis(req)
+	require.NoError(t, err)
+	periodReq := req.TOPSISRequest
+	periodReq.Alternatives = req.Periods[0].Alternatives
+	expected, err := Topsis(periodReq)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Results, response.Periods[0].Results)
+	// minmax pada agregat: rentang Quality 84 - 66, S2 bernilai 1 dikali bobot roc
+	assert.InDelta(t, 18, response.Aggregate.NormalizationFactors["Quality"], 1e-9)
+	assert.InDelta(t, expected.Weighting.Weights["Quality"], response.Aggregate.Results[0].WeightedValues["Quality"], 1e-9)
+
+	req.Periods[1].Alternatives = req.Periods[1].Alternatives[:1]
+	_, err = DynamicTopsis(req)
+	assert.Error(t, err)
+}
+
+func TestDynamicTopsisExcludedAlternative(t *testing.T) {
+	req := helperTopsis.DynamicTOPSISRequest{
+		TOPSISRequest: helperTopsis.TOPSISRequest{
+			Criteria: []helperTopsis.Criterion{
+				{Name: "Quality", Weight: 0.5, Type: helperTopsis.Benefit},
+				{Name: "Price", Weight: 0.5, Type: helperTopsis.Cost},
+			},
+			MissingPolicy: helperTopsis.MissingExclude,
+		},
+		Periods: []helperTopsis.TOPSISPeriod{
+			{Alternatives: []helperTopsis.Alternative{
+				{Name: "S1", Values: map[string]float64{"Quality": 90, "Price": 10}},
+				{Name: "S2", Values: map[string]float64{"Quality": 60, "Price": 20}},
+				{Name: "S3", Values: map[string]float64{"Quality": 70, "Price": 15}},
+			}},
+			{Alternatives: []helperTopsis.Alternative{
+				{Name: "S1", Values: map[string]float64{"Quality": 80, "Price": 12}},
+				{Name: "S2", Values: map[string]float64{"Quality": 65, "Price": 18}},
+				{Name: "S3", Values: map[string]float64{"Quality": 75}},
+			}},
+		},
+	}
+	response, err := DynamicTopsis(req)
+	require.NoError(t, err)
+	require.Len(t, response.Periods, 2)
+	assert.Nil(t, response.Periods[0].Missing)
+	require.NotNil(t, response.Periods[1].Missing)
+	assert.Equal(t, []string{"S3"}, response.Periods[1].Missing.Excluded)
+	assert.Len(t, response.Periods[1].Results, 2)
+
+	require.Len(t, response.Aggregate.Results, 2)
+	for _, result := range response.Aggregate.Results {
+		assert.NotEqual(t, "S3", result.Name)
+	}
+	require.Len(t, response.Trajectories, 3)
+	last := response.Trajectories[2]
+	assert.Equal(t, "S3", last.Name)
+	assert.Equal(t, 0, last.Ranks[1])
+	assert.Greater(t, last.Ranks[0], 0)
+}
+
+func TestTopsisCriteriaTree(t *testing.T) {
+	body := `{
+		"criteriaTree": [
+			{"name": "Teaching", "weight": 0.6, "children": [
+				{"name": "Microteaching", "weight": 0.7, "type": "benefit"},
+				{"name": "LessonPlan", "weight": 0.3, "type": "benefit"}
+			]},
+			{"name": "Interview", "weight": 0.4, "type": "benefit"}
+		],
+		"alternatives": [
+			{"name": "A", "values": {"Microteaching": 90, "LessonPlan": 60, "Interview": 70}},
+			{"name": "B", "values": {"Microteaching": 70, "LessonPlan": 90, "Interview": 85}},
+			{"name": "C", "values": {"Microteaching": 80, "LessonPlan": 80, "Interview": 60}}
+		]
+	}`
+	var req helperTopsis.TOPSISRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	require.Len(t, response.Results, 3)
+
+	require.Len(t, response.Branches, 1)
+	branch := response.Branches[0]
+	assert.Equal(t, "Teaching", branch.Branch)
+	assert.InDelta(t, 0.6, branch.GlobalWeight, 1e-9)
+	assert.Equal(t, []string{"Microteaching", "LessonPlan"}, branch.Criteria)
+	require.Len(t, branch.Results, 3)
+	assert.Equal(t, 1, branch.Results[0].Rank)
+
+	flat := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Microteaching", Weight: 0.42, Type: helperTopsis.Benefit},
+			{Name: "LessonPlan", Weight: 0.18, Type: helperTopsis.Benefit},
+			{Name: "Interview", Weight: 0.4, Type: helperTopsis.Benefit},
+		},
+		Alternatives: req.Alternatives,
+	}
+	flatResponse, err := Topsis(flat)
+	require.NoError(t, err)
+	for i := range flatResponse.Results {
+		assert.Equal(t, flatResponse.Results[i].Name, response.Results[i].Name)
+		assert.InDelta(t, flatResponse.Results[i].ClosenessValue, response.Results[i].ClosenessValue, 1e-9)
+	}
+
+	// cabang memakai sub-matriks kovarians daunnya, sama dengan TOPSIS yang
+	// hanya berisi kriteria cabang
+	covariance := map[string]map[string]float64{
+		"Microteaching": {"Microteaching": 1, "LessonPlan": 0.6, "Interview": 0.1},
+		"LessonPlan":    {"Microteaching": 0.6, "LessonPlan": 1, "Interview": 0},
+		"Interview":     {"Microteaching": 0.1, "LessonPlan": 0, "Interview": 1},
+	}
+	req.Distance = helperTopsis.DistanceMahalanobis
+	req.Covariance = covariance
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	teaching, err := Topsis(helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Microteaching", Weight: 0.7, Type: helperTopsis.Benefit},
+			{Name: "LessonPlan", Weight: 0.3, Type: helperTopsis.Benefit},
+		},
+		Alternatives: req.Alternatives,
+		Distance:     helperTopsis.DistanceMahalanobis,
+		Covariance: map[string]map[string]float64{
+			"Microteaching": {"Microteaching": 1, "LessonPlan": 0.6},
+			"LessonPlan":    {"Microteaching": 0.6, "LessonPlan": 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Branches, 1)
+	for i, score := range response.Branches[0].Results {
+		assert.Equal(t, teaching.Results[i].Name, score.Name)
+		assert.InDelta(t, teaching.Results[i].ClosenessValue, score.ClosenessValue, 1e-9)
+	}
+	req.Distance = ""
+	req.Covariance = nil
+
+	req.CriteriaTree[0].Children[1].Weight = 0.5
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "Teaching")
+}
+
+func TestTopsisLinguisticScale(t *testing.T) {
+	body := `{
+		"criteria": [
+			{"name": "Wawancara", "weight": 0.5, "type": "benefit",
+				"scale": {"name": "rubrik", "labels": {"Kurang": 1, "Cukup": 2, "Baik": 3, "Sangat Baik": 4}}},
+			{"name": "IPK", "weight": 0.5, "type": "benefit"}
+		],
+		"alternatives": [
+			{"name": "A", "values": {"Wawancara": "Sangat Baik", "IPK": 3.2}},
+			{"name": "B", "values": {"Wawancara": "Cukup", "IPK": 3.4}},
+			{"name": "C", "values": {"Wawancara": 3, "IPK": 3.0}}
+		]
+	}`
+	var req helperTopsis.TOPSISRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+
+	resultA, ok := findResult(response.Results, "A")
+	require.True(t, ok)
+	assert.Equal(t, "Sangat Baik", resultA.Labels["Wawancara"])
+	assert.Equal(t, 4.0, resultA.LabelValues["Wawancara"])
+	resultC, ok := findResult(response.Results, "C")
+	require.True(t, ok)
+	assert.Empty(t, resultC.Labels)
+	assert.InDelta(t, 4/math.Sqrt(29), resultA.NormalizedValues["Wawancara"], 1e-9)
+
+	req.Alternatives[1].Labels["Wawancara"] = "Luar Biasa"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "unknown label")
+
+	req.Alternatives[1].Labels = map[string]string{"Wawancara": "Cukup", "IPK": "Baik"}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "has no scale")
+}
+
+func TestTopsisMissingValues(t *testing.T) {
+	newRequest := func() helperTopsis.TOPSISRequest {
+		return helperTopsis.TOPSISRequest{
+			Criteria: []helperTopsis.Criterion{
+				{Name: "Tes", Weight: 0.4, Type: helperTopsis.Benefit},
+				{Name: "Biaya", Weight: 0.3, Type: helperTopsis.Cost, MissingPolicy: helperTopsis.MissingWorst},
+				{Name: "Wawancara", Weight: 0.3, Type: helperTopsis.Benefit, MissingPolicy: helperTopsis.MissingExclude},
+			},
+			Alternatives: []helperTopsis.Alternative{
+				{Name: "A", Values: map[string]float64{"Tes": 80, "Biaya": 10, "Wawancara": 3}},
+				{Name: "B", Values: map[string]float64{"Biaya": 30, "Wawancara": 4}},
+				{Name: "C", Values: map[string]float64{"Tes": 70, "Wawancara": 2}},
+				{Name: "D", Values: map[string]float64{"Tes": 60, "Biaya": 20}},
+				{Name: "E", Values: map[string]float64{"Tes": 90, "Biaya": 15, "Wawancara": 5}},
+			},
+		}
+	}
+
+	_, err := Topsis(newRequest())
+	assert.ErrorContains(t, err, "missing Value for criteria Tes")
+
+	req := newRequest()
+	req.MissingPolicy = helperTopsis.MissingMedian
+	response, err := Topsis(req)
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Missing)
+	assert.Equal(t, []string{"D"}, response.Missing.Excluded)
+	assert.ElementsMatch(t, []helperTopsis.ImputedValue{
+		{Alternative: "B", Criterion: "Tes", Policy: helperTopsis.MissingMedian, Value: 80},
+		{Alternative: "C", Criterion: "Biaya", Policy: helperTopsis.MissingWorst, Value: 30},
+	}, response.Missing.Imputed)
+	assert.Len(t, response.Results, 4)
+
+	req.MissingPolicy = "guess"
+	_, err = Topsis(req)
+	assert.Error(t, err)
+
+	req = newRequest()
+	req.MissingPolicy = helperTopsis.MissingExclude
+	req.Alternatives = []helperTopsis.Alternative{req.Alternatives[1], req.Alternatives[3]}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "No Alternative left after applying missing value policy exclude")
+
+	var alt helperTopsis.Alternative
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "B", "values": {"Tes": null, "Biaya": 30, "Wawancara": 4}}`), &alt))
+	assert.NotContains(t, alt.Values, "Tes")
+	req = newRequest()
+	req.MissingPolicy = helperTopsis.MissingMedian
+	req.Alternatives[1] = alt
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.Contains(t, response.Missing.Imputed, helperTopsis.ImputedValue{Alternative: "B", Criterion: "Tes", Policy: helperTopsis.MissingMedian, Value: 80})
+}
+
+func TestTopsisDerivedCriteria(t *testing.T) {
+	req := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Harga", Helper: true},
+			{Name: "Kapasitas", Helper: true},
+			{Name: "HargaPerUnit", Weight: 0.5, Type: helperTopsis.Cost, Expression: "Harga / Kapasitas"},
+			{Name: "Tes", Weight: 0.5, Type: helperTopsis.Benefit, Expression: "(Tes1 + Tes2) / 2"},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"Harga": 100, "Kapasitas": 4, "Tes1": 70, "Tes2": 90}},
+			{Name: "B", Values: map[string]float64{"Harga": 90, "Kapasitas": 2, "Tes1": 60, "Tes2": 60}},
+			{Name: "C", Values: map[string]float64{"Harga": 80, "Kapasitas": 0, "Tes1": 50, "Tes2": 50}},
+			{Name: "D", Values: map[string]float64{"Harga": 50, "Kapasitas": 5, "Tes1": 40}},
+		},
+	}
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "A", response.Results[0].Name)
+	assert.InDelta(t, 25/math.Sqrt(25*25+45*45), response.Results[0].NormalizedValues["HargaPerUnit"], 1e-9)
+	assert.NotContains(t, response.Results[0].WeightedValues, "Harga")
+	assert.NotContains(t, response.NormalizationFactors, "Kapasitas")
+
+	require.Len(t, response.ExpressionErrors, 2)
+	assert.Equal(t, helperTopsis.ExpressionError{
+		Alternative: "C", Criterion: "HargaPerUnit", Error: "division by zero",
+	}, response.ExpressionErrors[0])
+	assert.Equal(t, "D", response.ExpressionErrors[1].Alternative)
+	assert.Contains(t, response.ExpressionErrors[1].Error, "Tes2")
+
+	req.Criteria[3].Expression = "(Tes1 + Tes2"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "Invalid expression for Tes")
+
+	req.Criteria[3].Expression = strings.Repeat("(", 100) + "Tes1" + strings.Repeat(")", 100)
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "nested deeper than")
+
+	req.Criteria[3].Expression = "Tes1 + Tes3"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "unknown variable Tes3")
+
+	req.Criteria[2].Expression = "Harga / Tes"
+	req.Criteria[3].Expression = "max(Tes1, Tes2)"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "derived criterion Tes is used before it is declared")
+
+	req.Criteria[2].Expression = "Harga / Kapasitas"
+	req.Criteria[3].Expression = "(kualitas_é + Tes2) / 2"
+	for i := range req.Alternatives {
+		req.Alternatives[i].Values["kualitas_é"] = req.Alternatives[i].Values["Tes1"]
+	}
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, "A", response.Results[0].Name)
+
+	req.Criteria[0].Weight = 0.1
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "must not have a weight")
+
+	maxHarga := 95.0
+	req.Criteria[0].Weight = 0
+	req.Criteria[0].Max = &maxHarga
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "helper criterion Harga must not have min or max")
+}
+
+func TestTopsisUnits(t *testing.T) {
+	registry := helperTopsis.DefaultUnitRegistry()
+	registry.Units["IDR"] = helperTopsis.UnitDefinition{Dimension: "currency", Factor: 1}
+	registry.Units["USD"] = helperTopsis.UnitDefinition{Dimension: "currency", Factor: 16000}
+	SetUnitRegistry(registry)
+	defer SetUnitRegistry(helperTopsis.DefaultUnitRegistry())
+
+	var req helperTopsis.TOPSISRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"criteria": [
+			{"name": "Harga", "weight": 0.6, "type": "cost", "unit": "IDR"},
+			{"name": "Panjang", "weight": 0.4, "type": "benefit", "unit": "m"}
+		],
+		"alternatives": [
+			{"name": "A", "values": {"Harga": {"value": 10, "unit": "USD"}, "Panjang": {"value": 250, "unit": "cm"}}},
+			{"name": "B", "values": {"Harga": 200000, "Panjang": 2}},
+			{"name": "C", "values": {"Harga": {"value": 120000}, "Panjang": 3}}
+		]
+	}`), &req))
+	assert.Equal(t, map[string]string{"Harga": "USD", "Panjang": "cm"}, req.Alternatives[0].Units)
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(160000*160000+200000*200000+120000*120000), response.NormalizationFactors["Harga"], 1e-6)
+
+	resultA, ok := findResult(response.Results, "A")
+	require.True(t, ok)
+	assert.Equal(t, helperTopsis.UnitValue{Value: 10, Unit: "USD"}, resultA.OriginalValues["Harga"])
+	assert.Equal(t, "IDR", resultA.ConvertedValues["Harga"].Unit)
+	assert.InDelta(t, 160000, resultA.ConvertedValues["Harga"].Value, 1e-9)
+	assert.InDelta(t, 2.5, resultA.ConvertedValues["Panjang"].Value, 1e-9)
+	resultB, ok := findResult(response.Results, "B")
+	require.True(t, ok)
+	assert.Empty(t, resultB.OriginalValues)
+
+	req.Alternatives[0].Units["Panjang"] = "kg"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "cannot convert kg")
+}
+
+func TestTopsisNormalization(t *testing.T) {
+	req := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Laba", Weight: 0.5, Type: helperTopsis.Benefit},
+			{Name: "Risiko", Weight: 0.3, Type: helperTopsis.Cost},
+			{Name: "Lokasi", Weight: 0.2, Type: helperTopsis.Benefit},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"Laba": -20, "Risiko": 2, "Lokasi": 3}},
+			{Name: "B", Values: map[string]float64{"Laba": 10, "Risiko": 3, "Lokasi": 3}},
+			{Name: "C", Values: map[string]float64{"Laba": 30, "Risiko": 5, "Lokasi": 3}},
+		},
+	}
+
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{helperTopsis.WarningNegativeValues, helperTopsis.WarningZeroVariance},
+		warningCodes(response.Warnings))
+
+	req.Normalization = helperTopsis.NormalizationShift
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{helperTopsis.WarningShifted, helperTopsis.WarningZeroVariance},
+		warningCodes(response.Warnings))
+	resultA, ok := findResult(response.Results, "A")
+	require.True(t, ok)
+	assert.Equal(t, 0.0, resultA.NormalizedValues["Laba"])
+	assert.InDelta(t, math.Sqrt(30*30+50*50), response.NormalizationFactors["Laba"], 1e-9)
+
+	req.Normalization = helperTopsis.NormalizationMinMax
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{helperTopsis.WarningZeroVariance}, warningCodes(response.Warnings))
+	resultB, ok := findResult(response.Results, "B")
+	require.True(t, ok)
+	assert.InDelta(t, 0.6, resultB.NormalizedValues["Laba"], 1e-9)
+	assert.Equal(t, 50.0, response.NormalizationFactors["Laba"])
+
+	req.Alternatives = []helperTopsis.Alternative{
+		{Name: "A", Values: map[string]float64{"Laba": 0, "Risiko": 2, "Lokasi": 3}},
+		{Name: "B", Values: map[string]float64{"Laba": 0, "Risiko": 3, "Lokasi": 4}},
+	}
+	req.Normalization = ""
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, []helperTopsis.CalculationWarning{{
+		Criterion: "Laba",
+		Code:      helperTopsis.WarningZeroFactor,
+		Message:   "normalization factor is 0, all normalized values are set to 0",
+	}}, response.Warnings)
+
+	req.Normalization = "log"
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "Invalid normalization")
+
+	for _, method := range []string{helperTopsis.NormalizationVector, helperTopsis.NormalizationShift, helperTopsis.NormalizationMinMax} {
+		req.Normalization = method
+		req.Alternatives = nil
+		factors, normalized, warnings := helperTopsis.Normalize(req)
+		assert.Equal(t, map[string]float64{"Laba": 0, "Risiko": 0, "Lokasi": 0}, factors, method)
+		assert.Empty(t, normalized, method)
+		assert.Empty(t, warnings, method)
+	}
+}
+
+func warningCodes(warnings []helperTopsis.CalculationWarning) []string {
+	codes := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		codes = append(codes, warning.Code)
+	}
+	return codes
+}
+
+func TestTopsisMahalanobis(t *testing.T) {
+	req := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Tes1", Weight: 0.4, Type: helperTopsis.Benefit},
+			{Name: "Tes2", Weight: 0.4, Type: helperTopsis.Benefit},
+			{Name: "Biaya", Weight: 0.2, Type: helperTopsis.Cost},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A", Values: map[string]float64{"Tes1": 80, "Tes2": 82, "Biaya": 30}},
+			{Name: "B", Values: map[string]float64{"Tes1": 70, "Tes2": 71, "Biaya": 20}},
+			{Name: "C", Values: map[string]float64{"Tes1": 90, "Tes2": 88, "Biaya": 45}},
+			{Name: "D", Values: map[string]float64{"Tes1": 60, "Tes2": 63, "Biaya": 15}},
+			{Name: "E", Values: map[string]float64{"Tes1": 85, "Tes2": 84, "Biaya": 25}},
+		},
+	}
+	euclidean, err := Topsis(req)
+	require.NoError(t, err)
+
+	req.Distance = helperTopsis.DistanceMahalanobis
+	req.Covariance = map[string]map[string]float64{
+		"Tes1":  {"Tes1": 1, "Tes2": 0, "Biaya": 0},
+		"Tes2":  {"Tes1": 0, "Tes2": 1, "Biaya": 0},
+		"Biaya": {"Tes1": 0, "Tes2": 0, "Biaya": 1},
+	}
+	identity, err := Topsis(req)
+	require.NoError(t, err)
+	for i, result := range identity.Results {
+		assert.Equal(t, euclidean.Results[i].Name, result.Name)
+		assert.InDelta(t, euclidean.Results[i].ClosenessValue, result.ClosenessValue, 1e-9)
+	}
+
+	req.Covariance = nil
+	estimated, err := Topsis(req)
+	require.NoError(t, err)
+	assert.Empty(t, estimated.Warnings)
+	for _, result := range estimated.Results {
+		assert.Greater(t, result.PositiveDistance+result.NegativeDistance, 0.0)
+	}
+	resultA, ok := findResult(estimated.Results, "A")
+	require.True(t, ok)
+	euclideanA, ok := findResult(euclidean.Results, "A")
+	require.True(t, ok)
+	assert.NotEqual(t, euclideanA.ClosenessValue, resultA.ClosenessValue)
+
+	req.Alternatives = req.Alternatives[:3]
+	singular, err := Topsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{helperTopsis.WarningSingularCovariance}, warningCodes(singular.Warnings))
+	assert.Len(t, singular.Results, 3)
+
+	req.Covariance = map[string]map[string]float64{
+		"Tes1":  {"Tes1": 1, "Tes2": 0.5, "Biaya": 0},
+		"Tes2":  {"Tes1": 0, "Tes2": 1, "Biaya": 0},
+		"Biaya": {"Tes1": 0, "Tes2": 0, "Biaya": 1},
+	}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "not symmetric")
+
+	req.Covariance = map[string]map[string]float64{
+		"Tes1":  {"Tes1": 1, "Tes2": 2, "Biaya": 0},
+		"Tes2":  {"Tes1": 2, "Tes2": 1, "Biaya": 0},
+		"Biaya": {"Tes1": 0, "Tes2": 0, "Biaya": 1},
+	}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "not positive-definite")
+
+	req.Covariance = map[string]map[string]float64{
+		"Tes1":  {"Tes1": 1, "Tes2": 1, "Biaya": 0},
+		"Tes2":  {"Tes1": 1, "Tes2": 1, "Biaya": 0},
+		"Biaya": {"Tes1": 0, "Tes2": 0, "Biaya": 1},
+	}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "not positive-definite")
+}
+
+func TestIntuitionisticTopsis(t *testing.T) {
+	ifn := func(mu, nu float64) helperTopsis.IFN {
+		return helperTopsis.IFN{Membership: mu, NonMembership: nu}
+	}
+	req := helperTopsis.IFTOPSISRequest{
+		Criteria: []helperTopsis.IFCriterion{
+			{Name: "Kompetensi", Type: helperTopsis.Benefit, Weight: ifn(0.9, 0.1)},
+			{Name: "Biaya", Type: helperTopsis.Cost, Weight: ifn(0.5, 0.4)},
+		},
+		Experts: []helperTopsis.IFExpert{
+			{Name: "P1", Alternatives: []helperTopsis.IFAlternative{
+				{Name: "A", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.6, 0.3), "Biaya": ifn(0.4, 0.5)}},
+				{Name: "B", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.5, 0.4), "Biaya": ifn(0.7, 0.2)}},
+				{Name: "C", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.9, 0.05), "Biaya": ifn(0.3, 0.6)}},
+			}},
+			{Name: "P2", Alternatives: []helperTopsis.IFAlternative{
+				{Name: "C", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.85, 0.1), "Biaya": ifn(0.35, 0.5)}},
+				{Name: "A", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.8, 0.1), "Biaya": ifn(0.5, 0.4)}},
+				{Name: "B", Values: map[string]helperTopsis.IFN{"Kompetensi": ifn(0.4, 0.5), "Biaya": ifn(0.8, 0.1)}},
+			}},
+		},
+	}
+
+	response, err := IntuitionisticTopsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"P1": 0.5, "P2": 0.5}, response.ExpertWeights)
+
+	aggregated := response.Aggregated["A"]["Kompetensi"]
+	assert.InDelta(t, 1-math.Sqrt(0.4*0.2), aggregated.Membership, 1e-9)
+	assert.InDelta(t, math.Sqrt(0.3*0.1), aggregated.NonMembership, 1e-9)
+	weighted := response.Weighted["A"]["Kompetensi"]
+	assert.InDelta(t, aggregated.Membership*0.9, weighted.Membership, 1e-9)
+	assert.InDelta(t, aggregated.NonMembership+0.1-aggregated.NonMembership*0.1, weighted.NonMembership, 1e-9)
+
+	require.Len(t, response.Results, 3)
+	assert.Equal(t, "C", response.Results[0].Name)
+	assert.Equal(t, "B", response.Results[2].Name)
+	assert.InDelta(t, aggregated.Score(), response.Results[1].NormalizedValues["Kompetensi"], 1e-9)
+	assert.Equal(t, response.Weighted["B"]["Biaya"].Membership, response.IdealNegative["Biaya"].Membership)
+
+	req.Experts[1].Alternatives[0].Values["Biaya"] = ifn(0.7, 0.5)
+	_, err = IntuitionisticTopsis(req)
+	assert.ErrorContains(t, err, "mu + nu must not exceed 1")
+
+	delete(req.Experts[1].Alternatives[0].Values, "Biaya")
+	_, err = IntuitionisticTopsis(req)
+	assert.ErrorContains(t, err, "missing Value for criteria Biaya")
+}
+
+func TestIntervalTopsis(t *testing.T) {
+	var req helperTopsis.IntervalTOPSISRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"criteria": [
+			{"name": "Biaya", "weight": 0.6, "type": "cost"},
+			{"name": "Kualitas", "weight": 0.4, "type": "benefit"}
+		],
+		"alternatives": [
+			{"name": "A", "values": {"Biaya": [12, 15], "Kualitas": 80}},
+			{"name": "B", "values": {"Biaya": [10, 20], "Kualitas": 75}},
+			{"name": "C", "values": {"Biaya": 14, "Kualitas": [60, 70]}}
+		]
+	}`), &req))
+	assert.Equal(t, helperTopsis.Interval{Lower: 12, Upper: 15}, req.Alternatives[0].Intervals["Biaya"])
+	assert.Equal(t, 13.5, req.Alternatives[0].Values["Biaya"])
+
+	response, err := IntervalTopsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, helperTopsis.IntervalMidpoint, response.Comparison)
+	require.Len(t, response.Results, 3)
+	for _, result := range response.Results {
+		assert.LessOrEqual(t, result.Closeness.Lower, result.Closeness.Upper)
+		assert.LessOrEqual(t, result.PositiveDistance.Lower, result.PositiveDistance.Upper)
+		assert.InDelta(t, result.Closeness.Midpoint(), result.Score, 1e-12)
+	}
+	for _, result := range response.Results {
+		if result.Name == "B" {
+			assert.Greater(t, result.Closeness.Upper-result.Closeness.Lower, 0.0)
+		}
+	}
+
+	req.Comparison = helperTopsis.IntervalPossibility
+	response, err = IntervalTopsis(req)
+	require.NoError(t, err)
+	assert.Equal(t, "A", response.Results[0].Name)
+	assert.Equal(t, "C", response.Results[2].Name)
+
+	// tanpa rentang hasilnya sama dengan TOPSIS biasa
+	crisp := helperTopsis.IntervalTOPSISRequest{TOPSISRequest: streamTestRequest()}
+	intervalResponse, err := IntervalTopsis(crisp)
+	require.NoError(t, err)
+	crispResponse, err := Topsis(crisp.TOPSISRequest)
+	require.NoError(t, err)
+	for i, result := range intervalResponse.Results {
+		assert.Equal(t, crispResponse.Results[i].Name, result.Name)
+		assert.InDelta(t, crispResponse.Results[i].ClosenessValue, result.Closeness.Lower, 1e-9)
+		assert.InDelta(t, crispResponse.Results[i].ClosenessValue, result.Closeness.Upper, 1e-9)
+	}
+
+	req.Alternatives[0].Intervals["Biaya"] = helperTopsis.Interval{Lower: 15, Upper: 12}
+	_, err = IntervalTopsis(req)
+	assert.ErrorContains(t, err, "greater than upper bound")
+}
+
+func TestDematel(t *testing.T) {
+	req := helperTopsis.DEMATELRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Pengalaman", Type: helperTopsis.Benefit},
+			{Name: "Tes", Type: helperTopsis.Benefit},
+			{Name: "Wawancara", Type: helperTopsis.Benefit},
+		},
+		Influence: map[string]map[string]float64{
+			"Pengalaman": {"Tes": 3, "Wawancara": 2},
+			"Tes":        {"Pengalaman": 1, "Wawancara": 4},
+			"Wawancara":  {"Pengalaman": 2, "Tes": 1},
+		},
+		DeriveWeights: true,
+	}
+	result, err := Dematel(req)
+	require.NoError(t, err)
+
+	// T = N + N·T dengan N = A / 6
+	names := []string{"Pengalaman", "Tes", "Wawancara"}
+	for _, from := range names {
+		for _, to := range names {
+			expected := req.Influence[from][to] / 6
+			for _, k := range names {
+				expected += req.Influence[from][k] / 6 * result.TotalRelation[k][to]
+			}
+			assert.InDelta(t, expected, result.TotalRelation[from][to], 1e-9)
+		}
+	}
+
+	relationSum, weightSum := 0.0, 0.0
+	for _, criterion := range result.Criteria {
+		relationSum += criterion.Relation
+		assert.InDelta(t, criterion.Dispatch+criterion.Receive, criterion.Prominence, 1e-12)
+	}
+	assert.InDelta(t, 0, relationSum, 1e-9)
+	assert.Equal(t, []string{"Pengalaman", "Tes"}, result.Cause)
+	assert.Equal(t, []string{"Wawancara"}, result.Effect)
+	require.Len(t, result.WeightedCriteria, 3)
+	for _, criterion := range result.WeightedCriteria {
+		weightSum += criterion.Weight
+		assert.Equal(t, helperTopsis.Benefit, criterion.Type)
+	}
+	assert.InDelta(t, 1, weightSum, 1e-9)
+	assert.NotEmpty(t, result.Links)
+
+	req.Influence["Tes"]["Tes"] = 2
+	_, err = Dematel(req)
+	assert.ErrorContains(t, err, "cannot influence itself")
+}
+
+func TestBestWorst(t *testing.T) {
+	req := helperTopsis.BWMRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Tes", Type: helperTopsis.Benefit},
+			{Name: "Wawancara", Type: helperTopsis.Benefit},
+			{Name: "Biaya", Type: helperTopsis.Cost},
+		},
+		Best:          "Tes",
+		Worst:         "Biaya",
+		BestToOthers:  map[string]float64{"Tes": 1, "Wawancara": 2, "Biaya": 4},
+		OthersToWorst: map[string]float64{"Tes": 4, "Wawancara": 2, "Biaya": 1},
+	}
+	result, err := BestWorst(req)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0/7, result.Weights["Tes"], 1e-9)
+	assert.InDelta(t, 2.0/7, result.Weights["Wawancara"], 1e-9)
+	assert.InDelta(t, 1.0/7, result.Weights["Biaya"], 1e-9)
+	assert.InDelta(t, 0, result.ConsistencyRatio, 1e-9)
+
+	req.Criteria = append(req.Criteria,
+		helperTopsis.Criterion{Name: "Pengalaman", Type: helperTopsis.Benefit},
+		helperTopsis.Criterion{Name: "Lokasi", Type: helperTopsis.Benefit})
+	req.BestToOthers = map[string]float64{"Tes": 1, "Wawancara": 2, "Biaya": 8, "Pengalaman": 4, "Lokasi": 3}
+	req.OthersToWorst = map[string]float64{"Tes": 8, "Wawancara": 6, "Biaya": 1, "Pengalaman": 3, "Lokasi": 2}
+	result, err = BestWorst(req)
+	require.NoError(t, err)
+	assert.Greater(t, result.Xi, 0.0)
+	assert.InDelta(t, result.Xi/4.47, result.ConsistencyRatio, 1e-12)
+
+	sum, deviation := 0.0, 0.0
+	for _, criterion := range result.WeightedCriteria {
+		w := criterion.Weight
+		sum += w
+		assert.GreaterOrEqual(t, w, 0.0)
+		deviation = math.Max(deviation, math.Abs(result.Weights["Tes"]-req.BestToOthers[criterion.Name]*w))
+		deviation = math.Max(deviation, math.Abs(w-req.OthersToWorst[criterion.Name]*result.Weights["Biaya"]))
+	}
+	assert.InDelta(t, 1, sum, 1e-9)
+	assert.InDelta(t, result.Xi, deviation, 1e-9)
+
+	topsisReq := streamTestRequest()
+	topsisReq.Criteria = result.WeightedCriteria
+	for i := range topsisReq.Alternatives {
+		topsisReq.Alternatives[i].Values = map[string]float64{
+			"Tes": float64(60 + 10*i), "Wawancara": 3, "Biaya": float64(10 + i), "Pengalaman": 2, "Lokasi": float64(i),
+		}
+	}
+	_, err = Topsis(topsisReq)
+	require.NoError(t, err)
+
+	req.OthersToWorst["Tes"] = 7
+	_, err = BestWorst(req)
+	assert.ErrorContains(t, err, "must be equal")
+}
+
+func TestTopsisWeighting(t *testing.T) {
+	roc, err := helperTopsis.RankWeights(helperTopsis.WeightingROC, 3)
+	require.NoError(t, err)
+	assert.InDelta(t, (1+1.0/2+1.0/3)/3, roc[0], 1e-12)
+	assert.InDelta(t, (1.0/2+1.0/3)/3, roc[1], 1e-12)
+	assert.InDelta(t, (1.0/3)/3, roc[2], 1e-12)
+	rankSum, err := helperTopsis.RankWeights(helperTopsis.WeightingRankSum, 3)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{3.0 / 6, 2.0 / 6, 1.0 / 6}, rankSum, 1e-12)
+	reciprocal, err := helperTopsis.RankWeights(helperTopsis.WeightingRankReciprocal, 2)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{2.0 / 3, 1.0 / 3}, reciprocal, 1e-12)
+
+	req := streamTestRequest()
+	names := make([]string, 0, len(req.Criteria))
+	for i := range req.Criteria {
+		names = append(names, req.Criteria[i].Name)
+		req.Criteria[i].Weight = 0
+	}
+	req.Weighting = &helperTopsis.Weighting{Method: helperTopsis.WeightingRankSum, Order: names}
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	require.NotNil(t, response.Weighting)
+	assert.Equal(t, helperTopsis.WeightingRankSum, response.Weighting.Method)
+	n := float64(len(names))
+	assert.InDelta(t, n/(n*(n+1)/2), response.Weighting.Weights[names[0]], 1e-12)
+
+	points := make(map[string]float64, len(names))
+	for i, name := range names {
+		points[name] = 100 - float64(20*i)
+	}
+	req.Weighting = &helperTopsis.Weighting{Method: helperTopsis.WeightingSwing, Points: points}
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	total := 0.0
+	for _, weight := range response.Weighting.Weights {
+		total += weight
+	}
+	assert.InDelta(t, 1, total, 1e-12)
+
+	req.Weighting = &helperTopsis.Weighting{Method: helperTopsis.WeightingROC, Order: names[:1]}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "weighting order has 1 criteria")
+}
+
+func TestLearnWeights(t *testing.T) {
+	req := helperTopsis.PreferenceRequest{
+		TOPSISRequest: streamTestRequest(),
+		Preferences: []helperTopsis.Preference{
+			{Better: "D", Worse: "C"},
+			{Better: "B", Worse: "A"},
+		},
+		Samples: 500,
+		Seed:    7,
+	}
+	result, err := LearnWeights(req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Satisfied)
+	assert.Empty(t, result.Unsatisfied)
+	// kandidat awal ditambah paling banyak MaxPreferencePasses putaran lokal
+	m := len(req.Criteria)
+	assert.LessOrEqual(t, result.SamplesEvaluated, req.Samples+2+m+MaxPreferencePasses*m*(m-1))
+	sum := 0.0
+	for _, weight := range result.Weights {
+		sum += weight
+	}
+	assert.InDelta(t, 1, sum, 1e-9)
+
+	topsisReq := streamTestRequest()
+	topsisReq.Criteria = result.WeightedCriteria
+	response, err := Topsis(topsisReq)
+	require.NoError(t, err)
+	ranks := make(map[string]int)
+	for _, r := range response.Results {
+		ranks[r.Name] = r.Rank
+	}
+	assert.Less(t, ranks["D"], ranks["C"])
+	assert.Less(t, ranks["B"], ranks["A"])
+
+	req.Preferences = append(req.Preferences, helperTopsis.Preference{Better: "C", Worse: "D"})
+	result, err = LearnWeights(req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Satisfied)
+	assert.Equal(t, 3, result.Total)
+	require.Len(t, result.Unsatisfied, 1)
+	require.Len(t, result.Outcomes, 3)
+	for _, outcome := range result.Outcomes {
+		assert.Equal(t, outcome.Margin > 0, outcome.Satisfied)
+	}
+
+	req.Preferences = []helperTopsis.Preference{{Better: "A", Worse: "Z"}}
+	_, err = LearnWeights(req)
+	assert.ErrorContains(t, err, "not found")
+
+	minIPK := 3.0
+	req.Criteria[0].Min = &minIPK
+	req.Preferences = []helperTopsis.Preference{{Better: "D", Worse: "C"}}
+	_, err = LearnWeights(req)
+	assert.ErrorContains(t, err, "Alternative D was rejected by the constraints")
+	req.Preferences = []helperTopsis.Preference{{Better: "B", Worse: "A"}}
+	result, err = LearnWeights(req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Satisfied)
+
+	req.Criteria[0].Min = nil
+	req.Dominance = helperTopsis.DominanceExclude
+	req.Alternatives = append(req.Alternatives,
+		helperTopsis.Alternative{Name: "F", Values: map[string]float64{"IPK": 3.0, "Skill": 75, "TransportCost": 30}})
+	req.Preferences = []helperTopsis.Preference{{Better: "F", Worse: "D"}}
+	_, err = LearnWeights(req)
+	assert.ErrorContains(t, err, "Alternative F was excluded as dominated")
+}
+
+func TestSortTopsis(t *testing.T) {
+	req := helperTopsis.SortingRequest{
+		TOPSISRequest: streamTestRequest(),
+		Mode:          helperTopsis.SortingBoundary,
+		Classes:       []helperTopsis.SortClass{{Name: "accepted"}, {Name: "waitlist"}, {Name: "rejected"}},
+		Boundaries: []map[string]float64{
+			{"IPK": 3.5, "Skill": 85, "TransportCost": 20},
+			{"IPK": 3.0, "Skill": 75, "TransportCost": 25},
+		},
+	}
+	req.Alternatives = append(req.Alternatives,
+		helperTopsis.Alternative{Name: "F", Values: map[string]float64{"IPK": 4, "Skill": 100, "TransportCost": 5}},
+		helperTopsis.Alternative{Name: "G", Values: map[string]float64{"IPK": 2.5, "Skill": 60, "TransportCost": 40}},
+	)
+	response, err := SortTopsis(req)
+	require.NoError(t, err)
+	require.Len(t, response.Assignments, 7)
+	require.Len(t, response.Profiles, 2)
+	classes := make(map[string]string)
+	for _, assignment := range response.Assignments {
+		classes[assignment.Name] = assignment.Class
+		assert.GreaterOrEqual(t, assignment.Margin, 0.0)
+		switch assignment.Class {
+		case "accepted":
+			assert.GreaterOrEqual(t, assignment.Closeness, response.Profiles[0].Closeness)
+			assert.InDelta(t, assignment.Closeness-response.Profiles[0].Closeness, assignment.Margin, 1e-12)
+			assert.Equal(t, "waitlist", assignment.NearestClass)
+		case "waitlist":
+			assert.Less(t, assignment.Closeness, response.Profiles[0].Closeness)
+			assert.GreaterOrEqual(t, assignment.Closeness, response.Profiles[1].Closeness)
+		default:
+			assert.Less(t, assignment.Closeness, response.Profiles[1].Closeness)
+		}
+	}
+	assert.Equal(t, "accepted", classes["F"])
+	assert.Equal(t, "rejected", classes["G"])
+	total := 0
+	for _, class := range response.Classes {
+		total += len(class.Members)
+	}
+	assert.Equal(t, 7, total)
+
+	req.Boundaries[0], req.Boundaries[1] = req.Boundaries[1], req.Boundaries[0]
+	_, err = SortTopsis(req)
+	assert.ErrorContains(t, err, "not worse")
+
+	req.Mode = helperTopsis.SortingCharacteristic
+	req.Boundaries = nil
+	req.Classes[0].Profile = map[string]float64{"IPK": 3.8, "Skill": 95, "TransportCost": 10}
+	req.Classes[1].Profile = map[string]float64{"IPK": 3.3, "Skill": 80, "TransportCost": 22}
+	req.Classes[2].Profile = map[string]float64{"IPK": 2.8, "Skill": 65, "TransportCost": 35}
+	response, err = SortTopsis(req)
+	require.NoError(t, err)
+	require.Len(t, response.Profiles, 3)
+	index := map[string]int{"accepted": 0, "waitlist": 1, "rejected": 2}
+	for _, assignment := range response.Assignments {
+		own := math.Abs(assignment.Closeness - response.Profiles[index[assignment.Class]].Closeness)
+		for _, profile := range response.Profiles {
+			assert.LessOrEqual(t, own, math.Abs(assignment.Closeness-profile.Closeness)+1e-12)
+		}
+	}
+
+	delete(req.Classes[2].Profile, "Skill")
+	_, err = SortTopsis(req)
+	assert.ErrorContains(t, err, "missing Value")
+
+	// nilai imputasi hanya dihitung dari alternatif sungguhan, bukan profil
+	req.Classes[2].Profile["Skill"] = 65
+	req.MissingPolicy = helperTopsis.MissingMean
+	req.Alternatives = append(req.Alternatives,
+		helperTopsis.Alternative{Name: "H", Values: map[string]float64{"IPK": 3.1, "TransportCost": 18}})
+	response, err = SortTopsis(req)
+	require.NoError(t, err)
+	require.NotNil(t, response.Missing)
+	require.Len(t, response.Missing.Imputed, 1)
+	assert.InDelta(t, 580.0/7, response.Missing.Imputed[0].Value, 1e-9)
+	assert.Len(t, response.Assignments, 8)
+}
+
+func TestSelectPortfolio(t *testing.T) {
+	req := helperTopsis.PortfolioRequest{
+		TOPSISRequest: streamTestRequest(),
+		CostAttribute: "Biaya",
+		Budget:        100,
+		MaxCount:      3,
+		Categories:    map[string]string{"A": "riset", "B": "riset", "C": "riset", "D": "layanan", "E": "layanan"},
+		Quotas:        map[string]helperTopsis.Quota{"layanan": {Min: 1}},
+	}
+	req.Criteria = append(req.Criteria, helperTopsis.Criterion{Name: "Biaya", Helper: true})
+	costs := map[string]float64{"A": 40, "B": 35, "C": 60, "D": 20, "E": 55}
+	for i := range req.Alternatives {
+		req.Alternatives[i].Values["Biaya"] = costs[req.Alternatives[i].Name]
+	}
+	result, err := SelectPortfolio(req)
+	require.NoError(t, err)
+
+	response, err := Topsis(req.TOPSISRequest)
+	require.NoError(t, err)
+	best := -1.0
+	for mask := 0; mask < 1<<len(response.Results); mask++ {
+		count, layanan, cost, value := 0, 0, 0.0, 0.0
+		for i, r := range response.Results {
+			if mask&(1<<i) != 0 {
+				count++
+				cost += costs[r.Name]
+				value += r.ClosenessValue
+				if req.Categories[r.Name] == "layanan" {
+					layanan++
+				}
+			}
+		}
+		if cost <= req.Budget && count <= req.MaxCount && layanan >= 1 {
+			best = math.Max(best, value)
+		}
+	}
+	assert.InDelta(t, best, result.TotalCloseness, 1e-12)
+	assert.LessOrEqual(t, result.TotalCost, req.Budget)
+	assert.InDelta(t, req.Budget-result.TotalCost, result.Leftover, 1e-12)
+	require.NotNil(t, result.BestExcluded)
+	for _, item := range result.Selected {
+		assert.NotEqual(t, result.BestExcluded.Name, item.Name)
+	}
+
+	req.MaxCount = 0
+	req.MinCount = 4
+	_, err = SelectPortfolio(req)
+	assert.ErrorContains(t, err, "no portfolio")
+
+	// biaya dalam USD dikonversi ke satuan kriteria sebelum dibandingkan
+	// dengan anggaran
+	registry := helperTopsis.DefaultUnitRegistry()
+	registry.Units["IDR"] = helperTopsis.UnitDefinition{Dimension: "currency", Factor: 1}
+	registry.Units["USD"] = helperTopsis.UnitDefinition{Dimension: "currency", Factor: 16000}
+	SetUnitRegistry(registry)
+	defer SetUnitRegistry(helperTopsis.DefaultUnitRegistry())
+	req.MinCount, req.MaxCount = 0, 3
+	req.Criteria[3].Unit = "IDR"
+	req.Budget = 100 * 16000
+	for i := range req.Alternatives {
+		req.Alternatives[i].Values = copyValues(req.Alternatives[i].Values)
+		req.Alternatives[i].Values["Biaya"] = costs[req.Alternatives[i].Name] * 16000
+	}
+	req.Alternatives[0].Values["Biaya"] = costs["A"]
+	req.Alternatives[0].Units = map[string]string{"Biaya": "USD"}
+	converted, err := SelectPortfolio(req)
+	require.NoError(t, err)
+	assert.InDelta(t, result.TotalCloseness, converted.TotalCloseness, 1e-12)
+	assert.InDelta(t, result.TotalCost*16000, converted.TotalCost, 1e-6)
+	for _, item := range converted.Selected {
+		assert.InDelta(t, costs[item.Name]*16000, item.Cost, 1e-6)
+	}
+}
+
+func TestGenerateAndRank(t *testing.T) {
+	maxCost := 150.0
+	req := helperTopsis.GenerationRequest{
+		TOPSISRequest: helperTopsis.TOPSISRequest{
+			Criteria: []helperTopsis.Criterion{
+				{Name: "Biaya", Weight: 0.5, Type: helperTopsis.Cost},
+				{Name: "Latensi", Weight: 0.3, Type: helperTopsis.Cost},
+				{Name: "Kinerja", Weight: 0.2, Type: helperTopsis.Benefit},
+			},
+		},
+		Dimensions: []helperTopsis.OptionDimension{
+			{Name: "Ukuran", Options: []helperTopsis.Option{
+				{Name: "kecil", Values: map[string]float64{"Biaya": 20, "Kinerja": 2}},
+				{Name: "besar", Values: map[string]float64{"Biaya": 80, "Kinerja": 8}},
+			}},
+			{Name: "Region", Options: []helperTopsis.Option{
+				{Name: "jakarta", Values: map[string]float64{"Biaya": 30, "Latensi": 10}},
+				{Name: "singapura", Values: map[string]float64{"Biaya": 20, "Latensi": 25}},
+				{Name: "tokyo", Values: map[string]float64{"Biaya": 25, "Latensi": 60}},
+			}},
+			{Name: "Storage", Options: []helperTopsis.Option{
+				{Name: "hdd", Values: map[string]float64{"Biaya": 5, "Kinerja": 3, "Latensi": 20}},
+				{Name: "ssd", Values: map[string]float64{"Biaya": 15, "Kinerja": 6, "Latensi": 5}},
+			}},
+		},
+		Aggregation: map[string]string{"Kinerja": helperTopsis.AggregateMin, "Latensi": helperTopsis.AggregateMax},
+		Exclude:     []map[string]string{{"Region": "tokyo", "Storage": "hdd"}},
+		Limits:      map[string]helperTopsis.ValueLimit{"Biaya": {Max: &maxCost}},
+	}
+	response, err := GenerateAndRank(req)
+	require.NoError(t, err)
+	// 12 kombinasi, 2 dibuang aturan exclude tokyo+hdd; kombinasi termahal
+	// besar/jakarta/ssd (125) masih di bawah batas biaya
+	assert.Equal(t, 10, response.Generated)
+	assert.Equal(t, 2, response.Pruned)
+	require.Len(t, response.Results, 10)
+	assert.Equal(t, map[string]string{"Ukuran": "besar", "Region": "jakarta", "Storage": "ssd"},
+		response.Choices["besar / jakarta / ssd"])
+	_, ok := response.Choices["kecil / tokyo / hdd"]
+	assert.False(t, ok)
+
+	alternatives, _, _, err := helperTopsis.GenerateAlternatives(req)
+	require.NoError(t, err)
+	for _, alt := range alternatives {
+		if alt.Name == "besar / singapura / ssd" {
+			assert.Equal(t, map[string]float64{"Biaya": 115, "Kinerja": 6, "Latensi": 25}, alt.Values)
+		}
+	}
+
+	maxCost = 60
+	response, err = GenerateAndRank(req)
+	require.NoError(t, err)
+	assert.Equal(t, 4, response.Generated)
+	assert.Equal(t, 8, response.Pruned)
+
+	// 10^7 kombinasi yang semuanya melewati batas biaya
+	options := make([]helperTopsis.Option, 10)
+	for i := range options {
+		options[i] = helperTopsis.Option{Name: fmt.Sprint(i), Values: map[string]float64{"Biaya": 10}}
+	}
+	large := req
+	large.Exclude = nil
+	large.Dimensions = nil
+	for i := 0; i < 7; i++ {
+		large.Dimensions = append(large.Dimensions, helperTopsis.OptionDimension{Name: fmt.Sprint("D", i), Options: options})
+	}
+	_, _, _, err = helperTopsis.GenerateAlternatives(large)
+	assert.ErrorContains(t, err, "combinations to check")
+
+	// jumlah kombinasi yang terpangkas tidak overflow
+	large.Dimensions = []helperTopsis.OptionDimension{{Name: "Paket", Options: []helperTopsis.Option{{Name: "hemat"}}}}
+	for i := 0; i < 25; i++ {
+		large.Dimensions = append(large.Dimensions, helperTopsis.OptionDimension{Name: fmt.Sprint("D", i), Options: options})
+	}
+	large.Exclude = []map[string]string{{"Paket": "hemat"}}
+	_, _, pruned, err := helperTopsis.GenerateAlternatives(large)
+	require.NoError(t, err)
+	assert.Equal(t, math.MaxInt, pruned)
+}
+
+func TestTopsisClustering(t *testing.T) {
+	req := helperTopsis.TOPSISRequest{
+		Criteria: []helperTopsis.Criterion{
+			{Name: "Tes", Weight: 0.5, Type: helperTopsis.Benefit},
+			{Name: "Wawancara", Weight: 0.5, Type: helperTopsis.Benefit},
+		},
+		Alternatives: []helperTopsis.Alternative{
+			{Name: "A1", Values: map[string]float64{"Tes": 90, "Wawancara": 90}},
+			{Name: "B1", Values: map[string]float64{"Tes": 60, "Wawancara": 60}},
+			{Name: "C1", Values: map[string]float64{"Tes": 20, "Wawancara": 20}},
+			{Name: "A2", Values: map[string]float64{"Tes": 89, "Wawancara": 91}},
+			{Name: "B2", Values: map[string]float64{"Tes": 61, "Wawancara": 59}},
+			{Name: "C2", Values: map[string]float64{"Tes": 21, "Wawancara": 19}},
+			{Name: "A3", Values: map[string]float64{"Tes": 91, "Wawancara": 89}},
+			{Name: "B3", Values: map[string]float64{"Tes": 59, "Wawancara": 61}},
+			{Name: "C3", Values: map[string]float64{"Tes": 19, "Wawancara": 21}},
+		},
+	}
+	expected := map[string]int{"A1": 1, "A2": 1, "A3": 1, "B1": 2, "B2": 2, "B3": 2, "C1": 3, "C2": 3, "C3": 3}
+	for _, clustering := range []helperTopsis.Clustering{
+		{Method: helperTopsis.ClusteringKMeans, Seed: 3},
+		{Method: helperTopsis.ClusteringHierarchical, K: 3},
+		{Method: helperTopsis.ClusteringHierarchical, Linkage: helperTopsis.LinkageSingle},
+		{Method: helperTopsis.ClusteringHierarchical, K: 3, Linkage: helperTopsis.LinkageComplete},
+	} {
+		req.Clustering = &clustering
+		response, err := Topsis(req)
+		require.NoError(t, err)
+		require.NotNil(t, response.Clustering)
+		assert.Equal(t, 3, response.Clustering.K)
+		assert.Greater(t, response.Clustering.Silhouette, 0.9)
+		for _, result := range response.Results {
+			assert.Equal(t, expected[result.Name], result.Cluster, result.Name)
+		}
+		top := response.Clustering.Clusters[0]
+		assert.Equal(t, 3, top.Size)
+		assert.Equal(t, response.Results[0].Name, top.Best)
+		assert.InDelta(t, response.Results[0].WeightedValues["Tes"], top.Centroid["Tes"], 0.01)
+	}
+
+	req.Clustering = &helperTopsis.Clustering{Method: helperTopsis.ClusteringKMeans, K: 10}
+	response, err := Topsis(req)
+	require.NoError(t, err)
+	assert.Nil(t, response.Clustering)
+	assert.Equal(t, []string{helperTopsis.WarningClusteringSkipped}, warningCodes(response.Warnings))
+	assert.Len(t, response.Results, 9)
+
+	// constraint menyisakan 6 alternatif, lebih sedikit dari k
+	minTes := 50.0
+	req.Criteria[0].Min = &minTes
+	req.Clustering = &helperTopsis.Clustering{Method: helperTopsis.ClusteringHierarchical, K: 7}
+	response, err = Topsis(req)
+	require.NoError(t, err)
+	assert.Nil(t, response.Clustering)
+	assert.Len(t, response.Results, 6)
+	assert.Contains(t, response.Warnings[0].Message, "greater than the 6 ranked alternatives")
+	req.Criteria[0].Min = nil
+
+	large := req
+	large.Alternatives = make([]helperTopsis.Alternative, helperTopsis.MaxHierarchicalAlternatives+1)
+	for i := range large.Alternatives {
+		large.Alternatives[i] = helperTopsis.Alternative{
+			Name:   fmt.Sprint("X", i),
+			Values: map[string]float64{"Tes": float64(i % 97), "Wawancara": float64(i % 89)},
+		}
+	}
+	large.Clustering = &helperTopsis.Clustering{Method: helperTopsis.ClusteringHierarchical, K: 3}
+	_, err = Topsis(large)
+	assert.ErrorContains(t, err, "use kmeans")
+
+	// silhouette alternatif sebanyak ini dihitung pada sampel
+	large.Clustering = &helperTopsis.Clustering{Method: helperTopsis.ClusteringKMeans, K: 3, Seed: 1}
+	response, err = Topsis(large)
+	require.NoError(t, err)
+	require.NotNil(t, response.Clustering)
+	assert.Equal(t, 3, response.Clustering.K)
+	assert.Greater(t, response.Clustering.Silhouette, 0.0)
+	assert.LessOrEqual(t, response.Clustering.Silhouette, 1.0)
+
+	large.Alternatives = make([]helperTopsis.Alternative, helperTopsis.MaxAutoKAlternatives+1)
+	for i := range large.Alternatives {
+		large.Alternatives[i] = helperTopsis.Alternative{
+			Name:   fmt.Sprint("X", i),
+			Values: map[string]float64{"Tes": float64(i % 97), "Wawancara": float64(i % 89)},
+		}
+	}
+	large.Clustering = &helperTopsis.Clustering{Method: helperTopsis.ClusteringKMeans}
+	_, err = Topsis(large)
+	assert.ErrorContains(t, err, "give k")
+
+	req.Clustering = &helperTopsis.Clustering{Method: "dbscan"}
+	_, err = Topsis(req)
+	assert.ErrorContains(t, err, "Invalid clustering method")
+}
@@ -0,0 +1,167 @@
+package topsis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+const (
+	DefaultUncertaintyIterations = 1000
+	MaxUncertaintyIterations     = 20000
+	DefaultUncertaintyConfidence = 0.95
+)
+
+// Uncertainty menjalankan Topsis berulang kali dengan nilai yang diambil dari
+// distribusi setiap sel (nilai tanpa distribusi tetap). Hasilnya adalah
+// interval kepercayaan persentil untuk closeness, peluang setiap peringkat,
+// dan matriks peluang "A mengalahkan B". Alternatif yang gugur karena batas
+// kriteria pada suatu iterasi dihitung sebagai kalah dari yang lolos.
+func Uncertainty(req helperTopsis.UncertaintyRequest) (helperTopsis.UncertaintyResult, error) {
+	iterations := req.Iterations
+	if iterations <= 0 {
+		iterations = DefaultUncertaintyIterations
+	}
+	if iterations > MaxUncertaintyIterations {
+		return helperTopsis.UncertaintyResult{}, fmt.Errorf("iterations must not exceed %d", MaxUncertaintyIterations)
+	}
+	confidence := req.Confidence
+	if confidence == 0 {
+		confidence = DefaultUncertaintyConfidence
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return helperTopsis.UncertaintyResult{}, fmt.Errorf("confidence must be between 0 and 1")
+	}
+
+	pointResponse, err := Topsis(req.TOPSISRequest)
+	if err != nil {
+		return helperTopsis.UncertaintyResult{}, err
+	}
+
+	n := len(req.Alternatives)
+	index := make(map[string]int, n)
+	for i, alt := range req.Alternatives {
+		index[alt.Name] = i
+	}
+	samples := make([][]float64, n)
+	rankCounts := make([][]int, n)
+	beatCounts := make([][]int, n)
+	for i := range rankCounts {
+		rankCounts[i] = make([]int, n)
+		beatCounts[i] = make([]int, n)
+	}
+	rejectedCounts := make([]int, n)
+
+	rng := rand.New(rand.NewSource(req.Seed))
+	for iteration := 0; iteration < iterations; iteration++ {
+		draw := req.TOPSISRequest
+		// pengelompokan tidak dipakai pada sampel, cukup pada hasil titik
+		draw.Clustering = nil
+		draw.Alternatives = make([]helperTopsis.Alternative, n)
+		for i, alt := range req.Alternatives {
+			if len(alt.Distributions) == 0 {
+				draw.Alternatives[i] = alt
+				continue
+			}
+			values := copyValues(alt.Values)
+			// urut berdasarkan nama supaya hasil dengan seed yang sama selalu identik
+			names := make([]string, 0, len(alt.Distributions))
+			for name := range alt.Distributions {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				values[name] = alt.Distributions[name].Sample(rng)
+			}
+			sampled := alt
+			sampled.Values = values
+			sampled.Distributions = nil
+			draw.Alternatives[i] = sampled
+		}
+
+		response, err := Topsis(draw)
+		if err != nil {
+			return helperTopsis.UncertaintyResult{}, err
+		}
+		closeness := make([]float64, n)
+		ranked := make([]bool, n)
+		for _, result := range response.Results {
+			i := index[result.Name]
+			closeness[i] = result.ClosenessValue
+			ranked[i] = true
+			rankCounts[i][result.Rank-1]++
+			samples[i] = append(samples[i], result.ClosenessValue)
+		}
+		for i := range ranked {
+			if !ranked[i] {
+				rejectedCounts[i]++
+				continue
+			}
+			for j := range ranked {
+				if i != j && (!ranked[j] || closeness[i] > closeness[j]) {
+					beatCounts[i][j]++
+				}
+			}
+		}
+	}
+
+	pointCloseness := make(map[string]float64, len(pointResponse.Results))
+	for _, result := range pointResponse.Results {
+		pointCloseness[result.Name] = result.ClosenessValue
+	}
+	result := helperTopsis.UncertaintyResult{
+		Iterations:       iterations,
+		Confidence:       confidence,
+		Alternatives:     make([]helperTopsis.AlternativeUncertainty, 0, n),
+		BeatsProbability: make(map[string]map[string]float64, n),
+	}
+	tail := (1 - confidence) / 2
+	for i, alt := range req.Alternatives {
+		sort.Float64s(samples[i])
+		summary := helperTopsis.AlternativeUncertainty{
+			Name:                alt.Name,
+			ClosenessValue:      pointCloseness[alt.Name],
+			ClosenessLower:      percentile(samples[i], tail),
+			ClosenessUpper:      percentile(samples[i], 1-tail),
+			RankProbabilities:   make([]float64, n),
+			RejectedProbability: float64(rejectedCounts[i]) / float64(iterations),
+		}
+		if len(samples[i]) > 0 {
+			sum := 0.0
+			for _, value := range samples[i] {
+				sum += value
+			}
+			summary.MeanCloseness = sum / float64(len(samples[i]))
+		}
+		for rank, count := range rankCounts[i] {
+			summary.RankProbabilities[rank] = float64(count) / float64(iterations)
+		}
+		result.Alternatives = append(result.Alternatives, summary)
+
+		result.BeatsProbability[alt.Name] = make(map[string]float64, n-1)
+		for j, other := range req.Alternatives {
+			if i != j {
+				result.BeatsProbability[alt.Name][other.Name] = float64(beatCounts[i][j]) / float64(iterations)
+			}
+		}
+	}
+	sort.SliceStable(result.Alternatives, func(i, j int) bool {
+		return result.Alternatives[i].MeanCloseness > result.Alternatives[j].MeanCloseness
+	})
+	return result, nil
+}
+
+// percentile menghitung persentil dengan interpolasi linear dari data terurut.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,12 @@
+package topsis
+
+import "github.com/nabilulilalbab/TopsisByme/helperTopsis"
+
+// unitRegistry dipakai prepareRequest untuk mengonversi nilai bersatuan.
+var unitRegistry = helperTopsis.DefaultUnitRegistry()
+
+// SetUnitRegistry mengganti tabel konversi satuan, dipanggil sekali saat
+// aplikasi mulai sebelum request pertama diproses.
+func SetUnitRegistry(registry helperTopsis.UnitRegistry) {
+	unitRegistry = registry
+}
@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleBestWorst godoc
+// @Summary Elicit criterion weights with the Best-Worst Method
+// @Description Given the best and worst criterion and the best-to-others and others-to-worst comparison vectors (1-9), solves the linear BWM model and returns optimal weights, the consistency ratio and criteria ready for a TOPSIS request
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.BWMRequest true "Best-Worst Method request"
+// @Success 200 {object} helper.Response{data=helperTopsis.BWMResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/bwm [post]
+func HandleBestWorst(c *gin.Context) {
+	var req helperTopsis.BWMRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson BWMRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.BestWorst(req)
+	if err != nil {
+		log.Printf("Error BestWorst : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Best Worst Method: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Best Worst Method", response))
+}
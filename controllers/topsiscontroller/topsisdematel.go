@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleDematel godoc
+// @Summary Analyze interdependent criteria with DEMATEL
+// @Description Accepts a direct-influence matrix over criterion names. Returns the total-relation matrix, prominence (D+R) and relation (D-R) per criterion, the cause and effect groups, the impact-relation links above the threshold and optionally DEMATEL-derived criterion weights
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.DEMATELRequest true "DEMATEL request"
+// @Success 200 {object} helper.Response{data=helperTopsis.DEMATELResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/dematel [post]
+func HandleDematel(c *gin.Context) {
+	var req helperTopsis.DEMATELRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson DEMATELRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.Dematel(req)
+	if err != nil {
+		log.Printf("Error Dematel : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Dematel: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Dematel", response))
+}
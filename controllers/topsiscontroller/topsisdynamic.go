@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisDynamic godoc
+// @Summary Execute multi-period dynamic TOPSIS
+// @Description Rank a time series of decision matrices. Returns each period's ranking, an aggregate ranking over the time-weighted matrix (equal, exponential decay or custom weights) and each alternative's rank trajectory
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.DynamicTOPSISRequest true "Dynamic TOPSIS request"
+// @Success 200 {object} helper.Response{data=helperTopsis.DynamicTOPSISResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/dynamic [post]
+func HandleTopsisDynamic(c *gin.Context) {
+	var req helperTopsis.DynamicTOPSISRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson DynamicTOPSISRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.DynamicTopsis(req)
+	if err != nil {
+		log.Printf("Error DynamicTopsis : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Dynamic Topsis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Dynamic Topsis", response))
+}
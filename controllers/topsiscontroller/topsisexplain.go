@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisExplain godoc
+// @Summary Explain why one alternative outranks another
+// @Description Break the difference in positive and negative distances between two alternatives into per-criterion contributions, ranked by impact, with a plain-language summary in Indonesian ("id") or English ("en")
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.ExplainRequest true "TOPSIS request with the two alternatives to compare"
+// @Success 200 {object} helper.Response{data=helperTopsis.PairwiseExplanation}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/explain [post]
+func HandleTopsisExplain(c *gin.Context) {
+	var req helperTopsis.ExplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson ExplainRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	explanation, err := topsis.ExplainPair(req)
+	if err != nil {
+		log.Printf("Error ExplainPair : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Explanation Topsis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Explanation Topsis", explanation))
+}
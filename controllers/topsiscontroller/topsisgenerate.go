@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisGenerate godoc
+// @Summary Generate alternatives from option grids and rank them
+// @Description Expands option dimensions into every combination, aggregates each option's criterion contributions with sum, max or min, drops combinations matched by exclude rules or value limits, and ranks the rest with TOPSIS
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.GenerationRequest true "Option grid request"
+// @Success 200 {object} helper.Response{data=helperTopsis.GenerationResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/generate [post]
+func HandleTopsisGenerate(c *gin.Context) {
+	var req helperTopsis.GenerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson GenerationRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.GenerateAndRank(req)
+	if err != nil {
+		log.Printf("Error GenerateAndRank : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Topsis Generate: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Topsis Generate", response))
+}
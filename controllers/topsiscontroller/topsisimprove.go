@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisImprove godoc
+// @Summary Suggest improvements to reach a target rank
+// @Description Find the smallest change to an alternative's criterion values that brings it to the target rank, respecting fixed criteria and bounds
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.ImprovementRequest true "TOPSIS request with the alternative, target rank, fixed criteria and bounds"
+// @Success 200 {object} helper.Response{data=helperTopsis.ImprovementSuggestion}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/improve [post]
+func HandleTopsisImprove(c *gin.Context) {
+	var req helperTopsis.ImprovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson ImprovementRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	suggestion, err := topsis.SuggestImprovement(req)
+	if err != nil {
+		log.Printf("Error SuggestImprovement : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Improvement Topsis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Improvement Topsis", suggestion))
+}
@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisInterval godoc
+// @Summary Execute interval-valued TOPSIS
+// @Description Values may be given as [lower, upper] intervals. Returns interval distances and closeness values plus a point ranking using the chosen comparison rule (midpoint, lower, upper or possibility)
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.IntervalTOPSISRequest true "Interval TOPSIS request"
+// @Success 200 {object} helper.Response{data=helperTopsis.IntervalTOPSISResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/interval [post]
+func HandleTopsisInterval(c *gin.Context) {
+	var req helperTopsis.IntervalTOPSISRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson IntervalTOPSISRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.IntervalTopsis(req)
+	if err != nil {
+		log.Printf("Error IntervalTopsis : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Interval Topsis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Interval Topsis", response))
+}
@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisIntuitionistic godoc
+// @Summary Execute intuitionistic fuzzy TOPSIS
+// @Description Ratings and criterion weights are intuitionistic fuzzy numbers (mu, nu). Ratings from several experts are aggregated with the IFWA operator, then ranked by closeness to the intuitionistic ideal solutions
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.IFTOPSISRequest true "Intuitionistic fuzzy TOPSIS request"
+// @Success 200 {object} helper.Response{data=helperTopsis.IFTOPSISResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/intuitionistic [post]
+func HandleTopsisIntuitionistic(c *gin.Context) {
+	var req helperTopsis.IFTOPSISRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson IFTOPSISRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.IntuitionisticTopsis(req)
+	if err != nil {
+		log.Printf("Error IntuitionisticTopsis : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Intuitionistic Topsis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Intuitionistic Topsis", response))
+}
@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisInverseWeights godoc
+// @Summary Find weights that make an alternative win
+// @Description Search the weight simplex for weight vectors that put the chosen alternative at rank 1, returning the closest such vector to the current weights and the per-criterion bounds of that region
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.InverseWeightRequest true "TOPSIS request with the chosen alternative"
+// @Success 200 {object} helper.Response{data=helperTopsis.InverseWeightResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/inverse-weights [post]
+func HandleTopsisInverseWeights(c *gin.Context) {
+	var req helperTopsis.InverseWeightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson InverseWeightRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	result, err := topsis.InverseWeights(req)
+	if err != nil {
+		log.Printf("Error InverseWeights : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Inverse Weight Analysis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Inverse Weight Analysis", result))
+}
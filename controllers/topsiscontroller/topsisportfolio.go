@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisPortfolio godoc
+// @Summary Select a portfolio of alternatives under a budget
+// @Description Ranks alternatives with TOPSIS, then picks the subset with the highest total closeness whose cost fits the budget and optional count limits and category quotas, using an exact branch-and-bound search
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.PortfolioRequest true "Portfolio selection request"
+// @Success 200 {object} helper.Response{data=helperTopsis.PortfolioResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/portfolio [post]
+func HandleTopsisPortfolio(c *gin.Context) {
+	var req helperTopsis.PortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson PortfolioRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.SelectPortfolio(req)
+	if err != nil {
+		log.Printf("Error SelectPortfolio : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Topsis Portfolio: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Topsis Portfolio", response))
+}
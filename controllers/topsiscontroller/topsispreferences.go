@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleLearnWeights godoc
+// @Summary Learn criterion weights from example preferences
+// @Description Searches for the weight vector that satisfies as many "better than" pairs as possible under the regular TOPSIS pipeline and reports the pairs it could not satisfy
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.PreferenceRequest true "Preference learning request"
+// @Success 200 {object} helper.Response{data=helperTopsis.PreferenceResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/learn-weights [post]
+func HandleLearnWeights(c *gin.Context) {
+	var req helperTopsis.PreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson PreferenceRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.LearnWeights(req)
+	if err != nil {
+		log.Printf("Error LearnWeights : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Learn Weights: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Learn Weights", response))
+}
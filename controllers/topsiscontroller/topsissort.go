@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisSort godoc
+// @Summary Sort alternatives into ordered classes (TOPSIS-Sort)
+// @Description Computes closeness of alternatives together with class boundary or characteristic profiles and assigns each alternative to a class, with its margin to the nearest class boundary
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.SortingRequest true "TOPSIS-Sort request"
+// @Success 200 {object} helper.Response{data=helperTopsis.SortingResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/sort [post]
+func HandleTopsisSort(c *gin.Context) {
+	var req helperTopsis.SortingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson SortingRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	response, err := topsis.SortTopsis(req)
+	if err != nil {
+		log.Printf("Error SortTopsis : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Topsis Sort: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Topsis Sort", response))
+}
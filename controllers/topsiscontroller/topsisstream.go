@@ -0,0 +1,32 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+)
+
+// HandleTopsisStream godoc
+// @Summary Execute streaming TOPSIS calculation
+// @Description Read alternatives as NDJSON after a header line with the criteria and return only the top-k results plus summary statistics for the rest. Supplying a reference (normalization factors and ideal solutions) in the header enables single-pass mode.
+// @Tags TOPSIS
+// @Accept application/x-ndjson
+// @Produce json
+// @Param topsis body helperTopsis.TOPSISStreamHeader true "Header line followed by one alternative per line"
+// @Success 200 {object} helper.Response{data=helperTopsis.TOPSISStreamResponse}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/stream [post]
+func HandleTopsisStream(c *gin.Context) {
+	response, err := topsis.TopsisStream(c.Request.Body)
+	if err != nil {
+		log.Printf("Error TopsisStream : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Calculation Topsis Stream: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Calculation Topsis Stream", response))
+}
@@ -0,0 +1,39 @@
+package topsiscontroller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helper"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// HandleTopsisUncertainty godoc
+// @Summary Monte Carlo uncertainty analysis for closeness scores
+// @Description Values may be given as distributions (normal, uniform or triangular). Returns a confidence interval for each closeness value, rank probabilities and a pairwise "A beats B" probability matrix
+// @Tags TOPSIS
+// @Accept json
+// @Produce json
+// @Param topsis body helperTopsis.UncertaintyRequest true "TOPSIS request with value distributions"
+// @Success 200 {object} helper.Response{data=helperTopsis.UncertaintyResult}
+// @Failure 400 {object} helper.Response
+// @Security BearerAuth
+// @Router /topsis/uncertainty [post]
+func HandleTopsisUncertainty(c *gin.Context) {
+	var req helperTopsis.UncertaintyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Error shouldBinjson UncertaintyRequest : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Request Body", nil))
+		return
+	}
+	result, err := topsis.Uncertainty(req)
+	if err != nil {
+		log.Printf("Error Uncertainty : %v", err.Error())
+		c.JSON(http.StatusBadRequest, helper.NewResponse("Failed Uncertainty Analysis: "+err.Error(), nil))
+		return
+	}
+	c.JSON(http.StatusOK, helper.NewResponse("Succes Uncertainty Analysis", result))
+}
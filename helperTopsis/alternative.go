@@ -0,0 +1,83 @@
+package helperTopsis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON menerima nilai alternatif berupa angka biasa, label skala
+// linguistik (misalnya "Baik"), rentang (misalnya [12, 15]), angka bersatuan
+// (misalnya {"value": 15, "unit": "USD"}), atau objek distribusi, misalnya
+// {"distribution": "normal", "mean": 80, "sd": 5}. Label disimpan di Labels
+// dan baru diubah ke angka oleh ApplyScales. Satuan disimpan di Units dan
+// dikonversi oleh ConvertUnits. Rentang disimpan di Intervals dan distribusi
+// di Distributions, sedangkan Values diisi titik tengah atau nilai harapannya
+// agar perhitungan TOPSIS biasa tetap berjalan. Nilai null dianggap kosong
+// dan diserahkan ke kebijakan nilai kosong.
+func (a *Alternative) UnmarshalJSON(data []byte) error {
+	type alternativeAlias Alternative
+	var raw struct {
+		alternativeAlias
+		Values map[string]json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*a = Alternative(raw.alternativeAlias)
+	if raw.Values == nil {
+		return nil
+	}
+	a.Values = make(map[string]float64, len(raw.Values))
+	for name, value := range raw.Values {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			continue
+		}
+		var number float64
+		if err := json.Unmarshal(value, &number); err == nil {
+			a.Values[name] = number
+			continue
+		}
+		var label string
+		if err := json.Unmarshal(value, &label); err == nil {
+			if a.Labels == nil {
+				a.Labels = make(map[string]string)
+			}
+			a.Labels[name] = label
+			continue
+		}
+		var interval Interval
+		if err := json.Unmarshal(value, &interval); err == nil {
+			if a.Intervals == nil {
+				a.Intervals = make(map[string]Interval)
+			}
+			a.Intervals[name] = interval
+			a.Values[name] = interval.Midpoint()
+			continue
+		}
+		var measured struct {
+			Value *float64 `json:"value"`
+			Unit  string   `json:"unit"`
+		}
+		if err := json.Unmarshal(value, &measured); err == nil && measured.Value != nil {
+			a.Values[name] = *measured.Value
+			if measured.Unit != "" {
+				if a.Units == nil {
+					a.Units = make(map[string]string)
+				}
+				a.Units[name] = measured.Unit
+			}
+			continue
+		}
+		var distribution ValueDistribution
+		if err := json.Unmarshal(value, &distribution); err != nil || distribution.Distribution == "" {
+			return fmt.Errorf("invalid value for criteria %s in alternative %s", name, a.Name)
+		}
+		if a.Distributions == nil {
+			a.Distributions = make(map[string]ValueDistribution)
+		}
+		a.Distributions[name] = distribution
+		a.Values[name] = distribution.ExpectedValue()
+	}
+	return nil
+}
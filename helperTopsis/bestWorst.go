@@ -0,0 +1,84 @@
+package helperTopsis
+
+import "fmt"
+
+// BWMRequest adalah masukan Best-Worst Method. BestToOthers berisi preferensi
+// kriteria terbaik terhadap setiap kriteria lain, OthersToWorst preferensi
+// setiap kriteria terhadap kriteria terburuk, keduanya skala 1 sampai 9.
+// Bobot pada Criteria diabaikan, tipe disalin ke WeightedCriteria.
+type BWMRequest struct {
+	Criteria      []Criterion        `json:"criteria"`
+	Best          string             `json:"best"`
+	Worst         string             `json:"worst"`
+	BestToOthers  map[string]float64 `json:"bestToOthers"`
+	OthersToWorst map[string]float64 `json:"othersToWorst"`
+}
+
+type BWMResult struct {
+	Weights map[string]float64 `json:"weights"`
+	// ξ optimal, simpangan terbesar terhadap perbandingan yang diberikan
+	Xi               float64 `json:"xi"`
+	ConsistencyIndex float64 `json:"consistencyIndex"`
+	ConsistencyRatio float64 `json:"consistencyRatio"`
+	// Criteria request dengan bobot BWM, siap dipakai di TOPSISRequest
+	WeightedCriteria []Criterion `json:"weightedCriteria"`
+}
+
+// bwmConsistencyIndex adalah indeks konsistensi Rezaei untuk a_BW = 1..9.
+var bwmConsistencyIndex = []float64{0, 0.44, 1.00, 1.63, 2.30, 3.00, 3.73, 4.47, 5.23}
+
+// BWMConsistencyIndex mengembalikan indeks konsistensi untuk preferensi
+// kriteria terbaik terhadap terburuk, diinterpolasi linear di antara nilai
+// bulat.
+func BWMConsistencyIndex(bestToWorst float64) float64 {
+	position := bestToWorst - 1
+	lower := int(position)
+	if lower >= len(bwmConsistencyIndex)-1 {
+		return bwmConsistencyIndex[len(bwmConsistencyIndex)-1]
+	}
+	fraction := position - float64(lower)
+	return bwmConsistencyIndex[lower] + fraction*(bwmConsistencyIndex[lower+1]-bwmConsistencyIndex[lower])
+}
+
+func ValidateBWMRequest(req BWMRequest) error {
+	if len(req.Criteria) < 2 {
+		return fmt.Errorf("BWM needs at least 2 criteria")
+	}
+	if req.Best == req.Worst {
+		return fmt.Errorf("best and worst criteria must be different")
+	}
+	names := make(map[string]bool, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		if names[criterion.Name] {
+			return fmt.Errorf("duplicate criteria name %s", criterion.Name)
+		}
+		names[criterion.Name] = true
+	}
+	if !names[req.Best] {
+		return fmt.Errorf("best criteria %s not found", req.Best)
+	}
+	if !names[req.Worst] {
+		return fmt.Errorf("worst criteria %s not found", req.Worst)
+	}
+	for _, criterion := range req.Criteria {
+		for label, vector := range map[string]map[string]float64{
+			"bestToOthers":  req.BestToOthers,
+			"othersToWorst": req.OthersToWorst,
+		} {
+			value, ok := vector[criterion.Name]
+			if !ok {
+				return fmt.Errorf("%s is missing criteria %s", label, criterion.Name)
+			}
+			if value < 1 || value > 9 {
+				return fmt.Errorf("%s value for %s must be between 1 and 9", label, criterion.Name)
+			}
+		}
+	}
+	if req.BestToOthers[req.Best] != 1 || req.OthersToWorst[req.Worst] != 1 {
+		return fmt.Errorf("a criterion compared with itself must be 1")
+	}
+	if req.BestToOthers[req.Worst] != req.OthersToWorst[req.Best] {
+		return fmt.Errorf("bestToOthers[%s] and othersToWorst[%s] must be equal", req.Worst, req.Best)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package helperTopsis
+
+// CheckConstraints memisahkan alternatif yang memenuhi batas min/max setiap
+// kriteria dari yang melanggarnya. Penyaringan ini dilakukan sebelum
+// normalisasi agar nilai yang tidak layak tidak bisa dikompensasi kriteria lain.
+func CheckConstraints(
+	alternatives []Alternative,
+	criteria []Criterion,
+) ([]Alternative, []RejectedAlternative) {
+	accepted := make([]Alternative, 0, len(alternatives))
+	var rejected []RejectedAlternative
+	for _, alt := range alternatives {
+		var violations []ConstraintViolation
+		for _, criterion := range criteria {
+			value := alt.Values[criterion.Name]
+			if criterion.Min != nil && value < *criterion.Min {
+				violations = append(violations, ConstraintViolation{
+					Criterion:  criterion.Name,
+					Constraint: "min",
+					Limit:      *criterion.Min,
+					Value:      value,
+				})
+			}
+			if criterion.Max != nil && value > *criterion.Max {
+				violations = append(violations, ConstraintViolation{
+					Criterion:  criterion.Name,
+					Constraint: "max",
+					Limit:      *criterion.Max,
+					Value:      value,
+				})
+			}
+		}
+		if len(violations) > 0 {
+			rejected = append(rejected, RejectedAlternative{Name: alt.Name, Violations: violations})
+			continue
+		}
+		accepted = append(accepted, alt)
+	}
+	return accepted, rejected
+}
@@ -0,0 +1,439 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// metode pengelompokan pada Clustering.Method
+const (
+	ClusteringKMeans       = "kmeans"
+	ClusteringHierarchical = "hierarchical"
+)
+
+// aturan jarak antar kelompok pada Clustering.Linkage
+const (
+	LinkageAverage  = "average"
+	LinkageComplete = "complete"
+	LinkageSingle   = "single"
+)
+
+const (
+	// batas atas k saat k dipilih otomatis dengan silhouette
+	DefaultClusteringMaxK = 8
+	// jumlah inisialisasi k-means++, diambil yang inersianya terkecil
+	kMeansRestarts   = 10
+	kMeansIterations = 100
+	// hierarchical butuh O(n²) memori dan O(n³) waktu
+	MaxHierarchicalAlternatives = 1000
+	// k otomatis menjalankan k-means untuk setiap kandidat k
+	MaxAutoKAlternatives = 5000
+	// silhouette O(n²) dihitung pada sampel sebanyak ini
+	silhouetteSampleSize = 1000
+)
+
+// peringatan jika clustering dilewati, misalnya k lebih besar dari jumlah
+// alternatif yang tersisa setelah constraint dan dominance
+const WarningClusteringSkipped = "clustering_skipped"
+
+// Clustering mengelompokkan alternatif berdasarkan WeightedValues. Jika K
+// kosong, k dipilih dari 2 sampai MaxK dengan silhouette tertinggi, hanya
+// untuk paling banyak MaxAutoKAlternatives alternatif.
+type Clustering struct {
+	Method string `json:"method"`
+	K      int    `json:"k,omitempty"`
+	MaxK   int    `json:"maxK,omitempty"`
+	// hanya untuk hierarchical, default average
+	Linkage string `json:"linkage,omitempty"`
+	// hanya untuk kmeans
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// ClusterSummary meringkas satu kelompok. Centroid adalah rata-rata
+// WeightedValues anggotanya dan Best anggota dengan closeness tertinggi.
+type ClusterSummary struct {
+	Cluster       int                `json:"cluster"`
+	Size          int                `json:"size"`
+	Members       []string           `json:"members"`
+	Best          string             `json:"best"`
+	MeanCloseness float64            `json:"meanCloseness"`
+	MinCloseness  float64            `json:"minCloseness"`
+	MaxCloseness  float64            `json:"maxCloseness"`
+	Centroid      map[string]float64 `json:"centroid"`
+}
+
+type ClusteringResult struct {
+	Method     string           `json:"method"`
+	K          int              `json:"k"`
+	Silhouette float64          `json:"silhouette"`
+	Clusters   []ClusterSummary `json:"clusters"`
+}
+
+func ValidateClustering(clustering *Clustering) error {
+	if clustering == nil {
+		return nil
+	}
+	if clustering.Method != ClusteringKMeans && clustering.Method != ClusteringHierarchical {
+		return fmt.Errorf("Invalid clustering method : %s", clustering.Method)
+	}
+	switch clustering.Linkage {
+	case "", LinkageAverage, LinkageComplete, LinkageSingle:
+	default:
+		return fmt.Errorf("Invalid linkage : %s", clustering.Linkage)
+	}
+	if clustering.K < 0 || clustering.MaxK < 0 {
+		return fmt.Errorf("clustering k must not be negative")
+	}
+	return nil
+}
+
+// ClusterResults mengisi Cluster pada setiap hasil dan mengembalikan
+// ringkasan kelompok. Nomor kelompok dimulai dari 1 dan diurutkan menurut
+// closeness terbaik anggotanya, jadi kelompok 1 memuat peringkat teratas.
+// Jika K lebih besar dari jumlah hasil, clustering dilewati dengan
+// peringatan karena jumlah itu baru diketahui setelah constraint dan
+// dominance.
+func ClusterResults(
+	results []TOPSISResult,
+	criteria []Criterion,
+	clustering Clustering,
+) (*ClusteringResult, []CalculationWarning, error) {
+	n := len(results)
+	if clustering.K > n {
+		return nil, []CalculationWarning{{
+			Code:    WarningClusteringSkipped,
+			Message: fmt.Sprintf("clustering k %d is greater than the %d ranked alternatives, clustering skipped", clustering.K, n),
+		}}, nil
+	}
+	if clustering.Method == ClusteringHierarchical && n > MaxHierarchicalAlternatives {
+		return nil, nil, fmt.Errorf(
+			"hierarchical clustering supports at most %d alternatives, use kmeans", MaxHierarchicalAlternatives)
+	}
+	if clustering.K == 0 && n > MaxAutoKAlternatives {
+		return nil, nil, fmt.Errorf(
+			"automatic k supports at most %d alternatives, give k", MaxAutoKAlternatives)
+	}
+	if n == 0 {
+		return &ClusteringResult{Method: clustering.Method, Clusters: []ClusterSummary{}}, nil, nil
+	}
+	points := make([][]float64, n)
+	for i, result := range results {
+		points[i] = make([]float64, len(criteria))
+		for j, criterion := range criteria {
+			points[i][j] = result.WeightedValues[criterion.Name]
+		}
+	}
+
+	var assign func(k int) []int
+	if clustering.Method == ClusteringKMeans {
+		assign = func(k int) []int {
+			return kMeans(points, k, clustering.Seed)
+		}
+	} else {
+		levels := agglomerate(points, clustering.Linkage)
+		assign = func(k int) []int {
+			return levels[n-k]
+		}
+	}
+
+	k := clustering.K
+	var labels []int
+	silhouette := 0.0
+	if k > 0 {
+		labels = assign(k)
+		silhouette = sampledSilhouette(points, labels, k)
+	} else {
+		maxK := clustering.MaxK
+		if maxK == 0 {
+			maxK = DefaultClusteringMaxK
+		}
+		maxK = min(maxK, n-1)
+		k, labels = 1, make([]int, n)
+		for candidate := 2; candidate <= maxK; candidate++ {
+			candidateLabels := assign(candidate)
+			if score := sampledSilhouette(points, candidateLabels, candidate); k == 1 || score > silhouette {
+				k, labels, silhouette = candidate, candidateLabels, score
+			}
+		}
+	}
+
+	// nomori ulang kelompok menurut urutan closeness tertinggi; kelompok
+	// k-means yang kosong karena titik kembar ikut hilang dari hitungan k
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return results[order[a]].ClosenessValue > results[order[b]].ClosenessValue
+	})
+	renumber := make(map[int]int, k)
+	for _, i := range order {
+		if _, ok := renumber[labels[i]]; !ok {
+			renumber[labels[i]] = len(renumber) + 1
+		}
+	}
+
+	k = len(renumber)
+	summaries := make([]ClusterSummary, k)
+	for c := range summaries {
+		summaries[c] = ClusterSummary{
+			Cluster:      c + 1,
+			Members:      []string{},
+			MinCloseness: math.Inf(1),
+			Centroid:     make(map[string]float64, len(criteria)),
+		}
+	}
+	for _, i := range order {
+		cluster := renumber[labels[i]]
+		results[i].Cluster = cluster
+		summary := &summaries[cluster-1]
+		if summary.Size == 0 {
+			summary.Best = results[i].Name
+		}
+		summary.Size++
+		summary.Members = append(summary.Members, results[i].Name)
+		summary.MeanCloseness += results[i].ClosenessValue
+		summary.MinCloseness = math.Min(summary.MinCloseness, results[i].ClosenessValue)
+		summary.MaxCloseness = math.Max(summary.MaxCloseness, results[i].ClosenessValue)
+		for j, criterion := range criteria {
+			summary.Centroid[criterion.Name] += points[i][j]
+		}
+	}
+	for c := range summaries {
+		size := float64(summaries[c].Size)
+		summaries[c].MeanCloseness /= size
+		for name := range summaries[c].Centroid {
+			summaries[c].Centroid[name] /= size
+		}
+	}
+	return &ClusteringResult{Method: clustering.Method, K: k, Silhouette: silhouette, Clusters: summaries}, nil, nil
+}
+
+// sampledSilhouette menghitung Silhouette pada paling banyak
+// silhouetteSampleSize titik berjarak indeks sama, agar hasilnya tetap
+// deterministik.
+func sampledSilhouette(points [][]float64, labels []int, k int) float64 {
+	n := len(points)
+	if n <= silhouetteSampleSize {
+		return Silhouette(points, labels, k)
+	}
+	sample := make([][]float64, silhouetteSampleSize)
+	sampleLabels := make([]int, silhouetteSampleSize)
+	for i := range sample {
+		index := i * n / silhouetteSampleSize
+		sample[i], sampleLabels[i] = points[index], labels[index]
+	}
+	return Silhouette(sample, sampleLabels, k)
+}
+
+// Silhouette adalah rata-rata (b - a) / max(a, b) semua titik, dengan a
+// jarak rata-rata ke anggota kelompok sendiri dan b jarak rata-rata terkecil
+// ke kelompok lain. Titik di kelompok beranggota satu bernilai 0.
+func Silhouette(points [][]float64, labels []int, k int) float64 {
+	if k < 2 || len(points) == 0 {
+		return 0
+	}
+	sizes := make([]int, k)
+	for _, label := range labels {
+		sizes[label]++
+	}
+	total := 0.0
+	for i := range points {
+		if sizes[labels[i]] == 1 {
+			continue
+		}
+		sums := make([]float64, k)
+		for j := range points {
+			if i != j {
+				sums[labels[j]] += pointDistance(points[i], points[j])
+			}
+		}
+		a := sums[labels[i]] / float64(sizes[labels[i]]-1)
+		b := math.Inf(1)
+		for c := range sums {
+			if c != labels[i] && sizes[c] > 0 {
+				b = math.Min(b, sums[c]/float64(sizes[c]))
+			}
+		}
+		if spread := math.Max(a, b); spread > 0 && !math.IsInf(b, 1) {
+			total += (b - a) / spread
+		}
+	}
+	return total / float64(len(points))
+}
+
+func pointDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// kMeans menjalankan algoritme Lloyd dari beberapa inisialisasi k-means++
+// dan mengembalikan label dengan inersia terkecil.
+func kMeans(points [][]float64, k int, seed int64) []int {
+	rng := rand.New(rand.NewSource(seed))
+	var best []int
+	bestInertia := math.Inf(1)
+	for restart := 0; restart < kMeansRestarts; restart++ {
+		centroids := kMeansPlusPlus(points, k, rng)
+		labels := make([]int, len(points))
+		inertia := 0.0
+		for iteration := 0; iteration < kMeansIterations; iteration++ {
+			changed := iteration == 0
+			inertia = 0
+			for i, point := range points {
+				nearest, nearestDistance := 0, math.Inf(1)
+				for c, centroid := range centroids {
+					if d := pointDistance(point, centroid); d < nearestDistance {
+						nearest, nearestDistance = c, d
+					}
+				}
+				if labels[i] != nearest {
+					labels[i] = nearest
+					changed = true
+				}
+				inertia += nearestDistance * nearestDistance
+			}
+			if !changed {
+				break
+			}
+			centroids = clusterCentroids(points, labels, centroids)
+		}
+		if inertia < bestInertia {
+			best, bestInertia = append([]int(nil), labels...), inertia
+		}
+	}
+	return best
+}
+
+// kMeansPlusPlus memilih pusat awal dengan peluang sebanding kuadrat jarak ke
+// pusat terdekat yang sudah terpilih.
+func kMeansPlusPlus(points [][]float64, k int, rng *rand.Rand) [][]float64 {
+	centroids := [][]float64{points[rng.Intn(len(points))]}
+	distances := make([]float64, len(points))
+	for len(centroids) < k {
+		sum := 0.0
+		for i, point := range points {
+			distances[i] = math.Inf(1)
+			for _, centroid := range centroids {
+				distances[i] = math.Min(distances[i], pointDistance(point, centroid))
+			}
+			distances[i] *= distances[i]
+			sum += distances[i]
+		}
+		next := len(centroids) % len(points)
+		if sum > 0 {
+			target := rng.Float64() * sum
+			for i, distance := range distances {
+				target -= distance
+				if target <= 0 && distance > 0 {
+					next = i
+					break
+				}
+			}
+		}
+		centroids = append(centroids, points[next])
+	}
+	return centroids
+}
+
+// clusterCentroids menghitung rata-rata setiap kelompok. Kelompok yang
+// kosong mempertahankan pusat lamanya.
+func clusterCentroids(points [][]float64, labels []int, previous [][]float64) [][]float64 {
+	centroids := make([][]float64, len(previous))
+	sizes := make([]int, len(previous))
+	for c := range centroids {
+		centroids[c] = make([]float64, len(points[0]))
+	}
+	for i, point := range points {
+		sizes[labels[i]]++
+		for j, value := range point {
+			centroids[labels[i]][j] += value
+		}
+	}
+	for c := range centroids {
+		if sizes[c] == 0 {
+			copy(centroids[c], previous[c])
+			continue
+		}
+		for j := range centroids[c] {
+			centroids[c][j] /= float64(sizes[c])
+		}
+	}
+	return centroids
+}
+
+// agglomerate menjalankan pengelompokan hierarkis bottom-up. levels[m] adalah
+// label setelah m penggabungan, yaitu pemotongan dendrogram menjadi n - m
+// kelompok dengan label 0..n-m-1.
+func agglomerate(points [][]float64, linkage string) [][]int {
+	n := len(points)
+	// distance[a][b] adalah jarak antar kelompok a dan b, diperbarui dengan
+	// rumus Lance-Williams setiap kali dua kelompok digabung
+	distance := make([][]float64, n)
+	for i := range distance {
+		distance[i] = make([]float64, n)
+		for j := range distance[i] {
+			distance[i][j] = pointDistance(points[i], points[j])
+		}
+	}
+	members := make([][]int, n)
+	for i := range members {
+		members[i] = []int{i}
+	}
+	active := make([]int, n)
+	for i := range active {
+		active[i] = i
+	}
+	levels := make([][]int, n)
+	levels[0] = clusterLabels(members, active, n)
+	for merge := 1; merge < n; merge++ {
+		bestA, bestB, bestDistance := 0, 1, math.Inf(1)
+		for a := 0; a < len(active); a++ {
+			for b := a + 1; b < len(active); b++ {
+				if d := distance[active[a]][active[b]]; d < bestDistance {
+					bestA, bestB, bestDistance = a, b, d
+				}
+			}
+		}
+		x, y := active[bestA], active[bestB]
+		for _, c := range active {
+			if c == x || c == y {
+				continue
+			}
+			d := mergedDistance(distance[x][c], distance[y][c], len(members[x]), len(members[y]), linkage)
+			distance[x][c], distance[c][x] = d, d
+		}
+		members[x] = append(members[x], members[y]...)
+		active = append(active[:bestB], active[bestB+1:]...)
+		levels[merge] = clusterLabels(members, active, n)
+	}
+	return levels
+}
+
+func clusterLabels(members [][]int, active []int, n int) []int {
+	labels := make([]int, n)
+	for label, cluster := range active {
+		for _, i := range members[cluster] {
+			labels[i] = label
+		}
+	}
+	return labels
+}
+
+// mergedDistance adalah jarak kelompok gabungan x∪y ke kelompok lain dari
+// jarak x dan y ke kelompok itu.
+func mergedDistance(fromX, fromY float64, sizeX, sizeY int, linkage string) float64 {
+	switch linkage {
+	case LinkageSingle:
+		return math.Min(fromX, fromY)
+	case LinkageComplete:
+		return math.Max(fromX, fromY)
+	default:
+		return (float64(sizeX)*fromX + float64(sizeY)*fromY) / float64(sizeX+sizeY)
+	}
+}
@@ -0,0 +1,234 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// aturan agregasi kontribusi opsi pada GenerationRequest.Aggregation
+const (
+	AggregateSum = "sum"
+	AggregateMax = "max"
+	AggregateMin = "min"
+)
+
+// batas jumlah kombinasi yang dibangkitkan setelah pemangkasan
+const MaxGeneratedAlternatives = 10000
+
+// batas jumlah kombinasi lengkap yang diperiksa terhadap Limits, supaya
+// kombinasi yang hampir semuanya terpangkas tetap tidak berjalan tanpa akhir
+const MaxVisitedCombinations = 1000000
+
+// Option adalah satu pilihan dalam dimensi beserta kontribusinya ke kriteria.
+type Option struct {
+	Name   string             `json:"name"`
+	Values map[string]float64 `json:"values"`
+}
+
+// OptionDimension adalah satu sumbu konfigurasi, misalnya ukuran server.
+type OptionDimension struct {
+	Name    string   `json:"name"`
+	Options []Option `json:"options"`
+}
+
+// ValueLimit memangkas kombinasi yang nilai agregatnya di luar rentang.
+type ValueLimit struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// GenerationRequest membangkitkan alternatif dari hasil kali kartesius
+// Dimensions. Nilai kriteria kombinasi adalah agregat kontribusi opsinya
+// menurut Aggregation (default sum). Kombinasi dibuang sebelum diranking jika
+// memuat semua pilihan pada salah satu Exclude (dimensi -> opsi) atau jika
+// nilai agregatnya melanggar Limits. Alternatives pada TOPSISRequest harus
+// kosong.
+type GenerationRequest struct {
+	TOPSISRequest
+	Dimensions  []OptionDimension     `json:"dimensions"`
+	Aggregation map[string]string     `json:"aggregation,omitempty"`
+	Exclude     []map[string]string   `json:"exclude,omitempty"`
+	Limits      map[string]ValueLimit `json:"limits,omitempty"`
+}
+
+type GenerationResponse struct {
+	TOPSISResponse
+	Generated int `json:"generated"`
+	Pruned    int `json:"pruned"`
+	// pilihan per dimensi untuk setiap alternatif yang dibangkitkan
+	Choices map[string]map[string]string `json:"choices"`
+}
+
+func ValidateGenerationRequest(req GenerationRequest) error {
+	if len(req.Alternatives) > 0 {
+		return fmt.Errorf("alternatives are generated from dimensions and must be empty")
+	}
+	if len(req.Dimensions) == 0 {
+		return fmt.Errorf("No dimensions Provided")
+	}
+	options := make(map[string]map[string]bool, len(req.Dimensions))
+	for _, dimension := range req.Dimensions {
+		if dimension.Name == "" {
+			return fmt.Errorf("dimension name must not be empty")
+		}
+		if options[dimension.Name] != nil {
+			return fmt.Errorf("dimension %s is listed twice", dimension.Name)
+		}
+		if len(dimension.Options) == 0 {
+			return fmt.Errorf("dimension %s has no options", dimension.Name)
+		}
+		options[dimension.Name] = make(map[string]bool, len(dimension.Options))
+		for _, option := range dimension.Options {
+			if option.Name == "" || strings.Contains(option.Name, " / ") {
+				return fmt.Errorf("dimension %s has an invalid option name %q", dimension.Name, option.Name)
+			}
+			if options[dimension.Name][option.Name] {
+				return fmt.Errorf("dimension %s lists option %s twice", dimension.Name, option.Name)
+			}
+			options[dimension.Name][option.Name] = true
+		}
+	}
+	for criterion, rule := range req.Aggregation {
+		if rule != AggregateSum && rule != AggregateMax && rule != AggregateMin {
+			return fmt.Errorf("Invalid aggregation for %s : %s", criterion, rule)
+		}
+	}
+	for _, rule := range req.Exclude {
+		if len(rule) == 0 {
+			return fmt.Errorf("exclude rule must not be empty")
+		}
+		for dimension, option := range rule {
+			if !options[dimension][option] {
+				return fmt.Errorf("exclude rule refers to unknown option %s of %s", option, dimension)
+			}
+		}
+	}
+	return nil
+}
+
+// GenerateAlternatives mengembangkan Dimensions menjadi alternatif. Nama
+// alternatif adalah nama opsi yang digabung dengan " / " sesuai urutan
+// dimensi. Kriteria yang tidak mendapat kontribusi dari opsi mana pun
+// dibiarkan kosong supaya ditangani kebijakan nilai kosong.
+func GenerateAlternatives(req GenerationRequest) ([]Alternative, map[string]map[string]string, int, error) {
+	var alternatives []Alternative
+	choices := make(map[string]map[string]string)
+	pruned, visited := 0, 0
+	chosen := make([]Option, 0, len(req.Dimensions))
+
+	var expand func(level int) error
+	expand = func(level int) error {
+		if excluded(req, chosen) {
+			pruned = saturatingAdd(pruned, combinationCount(req.Dimensions[level:]))
+			return nil
+		}
+		if level < len(req.Dimensions) {
+			for _, option := range req.Dimensions[level].Options {
+				chosen = append(chosen, option)
+				if err := expand(level + 1); err != nil {
+					return err
+				}
+				chosen = chosen[:len(chosen)-1]
+			}
+			return nil
+		}
+
+		if visited == MaxVisitedCombinations {
+			return fmt.Errorf("more than %d combinations to check, add exclude rules or fewer options", MaxVisitedCombinations)
+		}
+		visited++
+		values := aggregateOptions(chosen, req.Aggregation)
+		if !withinLimits(values, req.Limits) {
+			pruned++
+			return nil
+		}
+		if len(alternatives) == MaxGeneratedAlternatives {
+			return fmt.Errorf("more than %d combinations left after pruning", MaxGeneratedAlternatives)
+		}
+		names := make([]string, len(chosen))
+		choice := make(map[string]string, len(chosen))
+		for i, option := range chosen {
+			names[i] = option.Name
+			choice[req.Dimensions[i].Name] = option.Name
+		}
+		name := strings.Join(names, " / ")
+		alternatives = append(alternatives, Alternative{Name: name, Values: values})
+		choices[name] = choice
+		return nil
+	}
+	if err := expand(0); err != nil {
+		return nil, nil, 0, err
+	}
+	return alternatives, choices, pruned, nil
+}
+
+// excluded memeriksa aturan Exclude yang semua dimensinya sudah dipilih.
+func excluded(req GenerationRequest, chosen []Option) bool {
+	for _, rule := range req.Exclude {
+		matched := 0
+		for i, option := range chosen {
+			if choice, ok := rule[req.Dimensions[i].Name]; ok && choice == option.Name {
+				matched++
+			}
+		}
+		if matched == len(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// combinationCount menghitung hasil kali jumlah opsi, dibatasi math.MaxInt
+// agar tidak overflow.
+func combinationCount(dimensions []OptionDimension) int {
+	count := 1
+	for _, dimension := range dimensions {
+		n := len(dimension.Options)
+		if n > 0 && count > math.MaxInt/n {
+			return math.MaxInt
+		}
+		count *= n
+	}
+	return count
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func aggregateOptions(options []Option, aggregation map[string]string) map[string]float64 {
+	values := make(map[string]float64)
+	for _, option := range options {
+		for criterion, value := range option.Values {
+			current, seen := values[criterion]
+			switch {
+			case !seen:
+				values[criterion] = value
+			case aggregation[criterion] == AggregateMax:
+				values[criterion] = math.Max(current, value)
+			case aggregation[criterion] == AggregateMin:
+				values[criterion] = math.Min(current, value)
+			default:
+				values[criterion] = current + value
+			}
+		}
+	}
+	return values
+}
+
+func withinLimits(values map[string]float64, limits map[string]ValueLimit) bool {
+	for criterion, limit := range limits {
+		value, ok := values[criterion]
+		if !ok {
+			continue
+		}
+		if (limit.Min != nil && value < *limit.Min) || (limit.Max != nil && value > *limit.Max) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+)
+
+// CriterionNode adalah satu simpul pohon kriteria. Weight adalah bobot lokal
+// terhadap saudara sekandungnya. Simpul tanpa Children adalah kriteria daun
+// yang wajib punya Type dan menjadi kolom pada Alternative.Values.
+type CriterionNode struct {
+	Name   string           `json:"name"`
+	Weight float64          `json:"weight"`
+	Type   string           `json:"type,omitempty"`
+	Min    *float64         `json:"min,omitempty"`
+	Max    *float64         `json:"max,omitempty"`
+	Scale  *LinguisticScale `json:"scale,omitempty"`
+	// kebijakan nilai kosong untuk daun, lihat Criterion.MissingPolicy
+	MissingPolicy string `json:"missingPolicy,omitempty"`
+	// ekspresi untuk daun turunan, lihat Criterion.Expression
+	Expression string          `json:"expression,omitempty"`
+	Unit       string          `json:"unit,omitempty"`
+	Children   []CriterionNode `json:"children,omitempty"`
+}
+
+// BranchResult adalah closeness setiap alternatif bila hanya kriteria daun di
+// bawah Branch yang diperhitungkan.
+type BranchResult struct {
+	Branch       string        `json:"branch"`
+	Level        int           `json:"level"`
+	GlobalWeight float64       `json:"globalWeight"`
+	Criteria     []string      `json:"criteria"`
+	Results      []BranchScore `json:"results"`
+}
+
+type BranchScore struct {
+	Name           string  `json:"name"`
+	ClosenessValue float64 `json:"closenessvalue"`
+	Rank           int     `json:"rank"`
+}
+
+// FlattenCriteriaTree memvalidasi bobot lokal setiap level (tidak negatif dan
+// berjumlah 1) lalu mengubah daun pohon menjadi []Criterion dengan bobot
+// global, yaitu hasil kali bobot lokal dari akar sampai daun.
+func FlattenCriteriaTree(nodes []CriterionNode) ([]Criterion, error) {
+	var criteria []Criterion
+	seen := make(map[string]bool)
+	if err := flattenCriteriaLevel(nodes, 1.0, "root", seen, &criteria); err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+func flattenCriteriaLevel(
+	nodes []CriterionNode,
+	parentWeight float64,
+	parentName string,
+	seen map[string]bool,
+	criteria *[]Criterion,
+) error {
+	if len(nodes) == 0 {
+		return fmt.Errorf("criteria %s has no children", parentName)
+	}
+	var weightSum float64
+	for _, node := range nodes {
+		if node.Weight < 0 {
+			return fmt.Errorf("criterion %s has negative weight", node.Name)
+		}
+		weightSum += node.Weight
+	}
+	if math.Abs(weightSum-1.0) > 0.0001 {
+		return fmt.Errorf("local weights under %s do not sum to 1.0 (sum: %f)", parentName, weightSum)
+	}
+	for _, node := range nodes {
+		if seen[node.Name] {
+			return fmt.Errorf("duplicate criteria name %s", node.Name)
+		}
+		seen[node.Name] = true
+		globalWeight := parentWeight * node.Weight
+		if len(node.Children) == 0 {
+			*criteria = append(*criteria, Criterion{
+				Name:          node.Name,
+				Weight:        globalWeight,
+				Type:          node.Type,
+				Min:           node.Min,
+				Max:           node.Max,
+				Scale:         node.Scale,
+				MissingPolicy: node.MissingPolicy,
+				Expression:    node.Expression,
+				Unit:          node.Unit,
+			})
+			continue
+		}
+		if err := flattenCriteriaLevel(node.Children, globalWeight, node.Name, seen, criteria); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package helperTopsis
+
+import "fmt"
+
+// DEMATELRequest berisi matriks pengaruh langsung antar kriteria, misalnya
+// skala 0 (tidak berpengaruh) sampai 4 (sangat berpengaruh). Influence[a][b]
+// adalah pengaruh a terhadap b; pasangan yang tidak diisi dianggap 0. Bobot
+// dan tipe pada Criteria diabaikan kecuali untuk disalin ke hasil bobot.
+type DEMATELRequest struct {
+	Criteria  []Criterion                   `json:"criteria"`
+	Influence map[string]map[string]float64 `json:"influence"`
+	// ambang peta hubungan pengaruh, default rata-rata matriks total
+	Threshold *float64 `json:"threshold,omitempty"`
+	// jika true, Criteria dikembalikan dengan bobot hasil DEMATEL
+	DeriveWeights bool `json:"deriveWeights,omitempty"`
+}
+
+// CriterionRelation adalah baris D dan R matriks total satu kriteria.
+// Relation > 0 berarti kriteria termasuk kelompok penyebab (cause).
+type CriterionRelation struct {
+	Name       string  `json:"name"`
+	Dispatch   float64 `json:"d"`
+	Receive    float64 `json:"r"`
+	Prominence float64 `json:"prominence"`
+	Relation   float64 `json:"relation"`
+	Group      string  `json:"group"`
+}
+
+// kelompok kriteria pada CriterionRelation.Group
+const (
+	DEMATELCause  = "cause"
+	DEMATELEffect = "effect"
+)
+
+// InfluenceLink adalah pengaruh total di atas ambang pada peta hubungan.
+type InfluenceLink struct {
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+	Value float64 `json:"value"`
+}
+
+type DEMATELResult struct {
+	TotalRelation map[string]map[string]float64 `json:"totalRelation"`
+	Criteria      []CriterionRelation           `json:"criteria"`
+	Cause         []string                      `json:"cause"`
+	Effect        []string                      `json:"effect"`
+	Threshold     float64                       `json:"threshold"`
+	Links         []InfluenceLink               `json:"links"`
+	Weights       map[string]float64            `json:"weights,omitempty"`
+	// Criteria request dengan bobot DEMATEL, siap dipakai di TOPSISRequest
+	WeightedCriteria []Criterion `json:"weightedCriteria,omitempty"`
+}
+
+func ValidateDEMATELRequest(req DEMATELRequest) error {
+	if len(req.Criteria) < 2 {
+		return fmt.Errorf("DEMATEL needs at least 2 criteria")
+	}
+	names := make(map[string]bool, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		if names[criterion.Name] {
+			return fmt.Errorf("duplicate criteria name %s", criterion.Name)
+		}
+		names[criterion.Name] = true
+	}
+	for from, row := range req.Influence {
+		if !names[from] {
+			return fmt.Errorf("influence given for unknown criteria %s", from)
+		}
+		for to, value := range row {
+			if !names[to] {
+				return fmt.Errorf("influence given for unknown criteria %s", to)
+			}
+			if value < 0 {
+				return fmt.Errorf("influence %s -> %s is negative", from, to)
+			}
+			if from == to && value != 0 {
+				return fmt.Errorf("criteria %s cannot influence itself", from)
+			}
+		}
+	}
+	return nil
+}
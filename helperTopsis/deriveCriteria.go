@@ -0,0 +1,122 @@
+package helperTopsis
+
+import "fmt"
+
+// ExpressionError mencatat alternatif yang gagal dihitung nilai kriteria
+// turunannya, misalnya karena pembagian dengan nol atau kolom yang kosong.
+type ExpressionError struct {
+	Alternative string `json:"alternative"`
+	Criterion   string `json:"criterion"`
+	Error       string `json:"error"`
+}
+
+// validateExpression memeriksa ekspresi satu kriteria turunan. declared
+// berisi nama kriteria sebelum kriteria ini, derived berisi nama semua
+// kriteria turunan, jadi kriteria turunan hanya boleh dipakai setelah
+// didefinisikan.
+func validateExpression(criterion Criterion, declared, derived map[string]bool) error {
+	if criterion.Helper {
+		return fmt.Errorf("helper criterion %s cannot have an expression", criterion.Name)
+	}
+	if criterion.Scale != nil {
+		return fmt.Errorf("derived criterion %s cannot have a scale", criterion.Name)
+	}
+	expr, err := ParseExpression(criterion.Expression)
+	if err != nil {
+		return fmt.Errorf("Invalid expression for %s : %w", criterion.Name, err)
+	}
+	for _, name := range expr.Variables {
+		if derived[name] && !declared[name] {
+			return fmt.Errorf("Invalid expression for %s : derived criterion %s is used before it is declared", criterion.Name, name)
+		}
+	}
+	return nil
+}
+
+// validateExpressionVariables memastikan setiap variabel ekspresi adalah
+// kriteria atau kolom yang ada pada minimal satu alternatif.
+func validateExpressionVariables(criteria []Criterion, alternatives []Alternative) error {
+	known := make(map[string]bool, len(criteria))
+	for _, criterion := range criteria {
+		known[criterion.Name] = true
+	}
+	for _, alt := range alternatives {
+		for name := range alt.Values {
+			known[name] = true
+		}
+	}
+	for _, criterion := range criteria {
+		if criterion.Expression == "" {
+			continue
+		}
+		// sudah divalidasi oleh ValidateCriteria
+		expr, _ := ParseExpression(criterion.Expression)
+		for _, name := range expr.Variables {
+			if !known[name] {
+				return fmt.Errorf("Invalid expression for %s : unknown variable %s", criterion.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// DeriveCriteria menghitung nilai setiap kriteria turunan per alternatif
+// sesuai urutan kriteria, jadi ekspresi boleh memakai kriteria turunan yang
+// didefinisikan sebelumnya. Alternatif yang ekspresinya gagal dihitung
+// dikeluarkan dari ranking dan dicatat di daftar error. Request harus sudah
+// lolos ValidateInput.
+func DeriveCriteria(req TOPSISRequest) (TOPSISRequest, []ExpressionError) {
+	type derived struct {
+		name string
+		expr *Expression
+	}
+	var expressions []derived
+	for _, criterion := range req.Criteria {
+		if criterion.Expression == "" {
+			continue
+		}
+		// sudah divalidasi oleh ValidateCriteria
+		expr, _ := ParseExpression(criterion.Expression)
+		expressions = append(expressions, derived{name: criterion.Name, expr: expr})
+	}
+	if len(expressions) == 0 {
+		return req, nil
+	}
+
+	var errs []ExpressionError
+	kept := make([]Alternative, 0, len(req.Alternatives))
+	for _, alt := range req.Alternatives {
+		values := make(map[string]float64, len(alt.Values)+len(expressions))
+		for name, value := range alt.Values {
+			values[name] = value
+		}
+		failed := false
+		for _, d := range expressions {
+			value, err := d.expr.Evaluate(values)
+			if err != nil {
+				errs = append(errs, ExpressionError{Alternative: alt.Name, Criterion: d.name, Error: err.Error()})
+				failed = true
+				break
+			}
+			values[d.name] = value
+		}
+		if failed {
+			continue
+		}
+		alt.Values = values
+		kept = append(kept, alt)
+	}
+	req.Alternatives = kept
+	return req, errs
+}
+
+// RankedCriteria mengembalikan kriteria tanpa kolom bantu (Helper).
+func RankedCriteria(criteria []Criterion) []Criterion {
+	ranked := make([]Criterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		if !criterion.Helper {
+			ranked = append(ranked, criterion)
+		}
+	}
+	return ranked
+}
@@ -0,0 +1,43 @@
+package helperTopsis
+
+// DetermineDominance mengembalikan, untuk setiap alternatif yang terdominasi,
+// daftar alternatif yang mendominasinya. Alternatif A mendominasi B jika A
+// tidak lebih buruk di semua kriteria dan lebih baik di minimal satu kriteria,
+// dengan arah benefit/cost diperhitungkan. Alternatif yang tidak muncul di
+// map hasil adalah anggota himpunan Pareto.
+func DetermineDominance(
+	alternatives []Alternative,
+	criteria []Criterion,
+) map[string][]string {
+	dominatedBy := make(map[string][]string)
+	for _, candidate := range alternatives {
+		for _, other := range alternatives {
+			if other.Name == candidate.Name {
+				continue
+			}
+			if dominates(other, candidate, criteria) {
+				dominatedBy[candidate.Name] = append(dominatedBy[candidate.Name], other.Name)
+			}
+		}
+	}
+	return dominatedBy
+}
+
+func dominates(a, b Alternative, criteria []Criterion) bool {
+	strictlyBetter := false
+	for _, criterion := range criteria {
+		valueA := a.Values[criterion.Name]
+		valueB := b.Values[criterion.Name]
+		// untuk cost nilai kecil lebih baik, jadi cukup balik tandanya
+		if criterion.Type == Cost {
+			valueA, valueB = -valueA, -valueB
+		}
+		if valueA < valueB {
+			return false
+		}
+		if valueA > valueB {
+			strictlyBetter = true
+		}
+	}
+	return strictlyBetter
+}
@@ -0,0 +1,56 @@
+package helperTopsis
+
+// skema bobot waktu untuk DynamicTOPSISRequest.TimeWeighting
+const (
+	TimeWeightingEqual       = "equal"
+	TimeWeightingExponential = "exponential"
+	TimeWeightingCustom      = "custom"
+)
+
+// TOPSISPeriod adalah satu snapshot matriks keputusan. Weight hanya dipakai
+// pada skema bobot waktu custom.
+type TOPSISPeriod struct {
+	Label        string        `json:"label"`
+	Alternatives []Alternative `json:"alternatives"`
+	Weight       float64       `json:"weight,omitempty"`
+}
+
+// DynamicTOPSISRequest berisi deret waktu matriks keputusan dengan kriteria
+// yang sama. Pada skema exponential, bobot periode ke-t (dari yang terlama)
+// sebanding dengan DecayRate^(T-1-t), sehingga periode terbaru paling berat.
+//
+// Opsi pada TOPSISRequest (normalisasi, jarak, dominasi, kebijakan nilai
+// kosong, pembobotan, pohon kriteria) berlaku untuk setiap periode dan hasil
+// agregat. Alternatives harus kosong karena alternatif ada di Periods.
+type DynamicTOPSISRequest struct {
+	TOPSISRequest
+	Periods       []TOPSISPeriod `json:"periods"`
+	TimeWeighting string         `json:"timeWeighting,omitempty"`
+	DecayRate     float64        `json:"decayRate,omitempty"`
+}
+
+// PeriodRanking adalah hasil satu periode. Missing dan ExpressionErrors
+// mencatat alternatif yang dibuang pada periode ini sebelum diranking.
+type PeriodRanking struct {
+	Label            string              `json:"label"`
+	Weight           float64             `json:"weight"`
+	Results          []TOPSISResult      `json:"results"`
+	Missing          *MissingValueReport `json:"missing,omitempty"`
+	ExpressionErrors []ExpressionError   `json:"expressionErrors,omitempty"`
+}
+
+// RankTrajectory berisi peringkat dan closeness satu alternatif di setiap
+// periode, sesuai urutan Periods.
+type RankTrajectory struct {
+	Name            string    `json:"name"`
+	Ranks           []int     `json:"ranks"`
+	ClosenessValues []float64 `json:"closenessValues"`
+}
+
+// DynamicTOPSISResponse.Aggregate adalah TOPSIS atas matriks rata-rata
+// tertimbang waktu dari semua periode.
+type DynamicTOPSISResponse struct {
+	Aggregate    TOPSISResponse   `json:"aggregate"`
+	Periods      []PeriodRanking  `json:"periods"`
+	Trajectories []RankTrajectory `json:"trajectories"`
+}
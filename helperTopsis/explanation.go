@@ -0,0 +1,41 @@
+package helperTopsis
+
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+// ExplainRequest adalah TOPSISRequest ditambah dua nama alternatif yang
+// ingin dibandingkan dan bahasa ringkasan (default "id").
+type ExplainRequest struct {
+	TOPSISRequest
+	AlternativeA string `json:"alternativeA"`
+	AlternativeB string `json:"alternativeB"`
+	Language     string `json:"language,omitempty"`
+}
+
+// CriterionContribution adalah bagian selisih jarak A dan B yang berasal dari
+// satu kriteria. Jumlah kontribusi semua kriteria sama dengan selisih jarak
+// total. Impact positif berarti kriteria ini menguntungkan A.
+type CriterionContribution struct {
+	Criterion                    string  `json:"criterion"`
+	WeightedValueA               float64 `json:"weightedValueA"`
+	WeightedValueB               float64 `json:"weightedValueB"`
+	PositiveDistanceContribution float64 `json:"positiveDistanceContribution"`
+	NegativeDistanceContribution float64 `json:"negativeDistanceContribution"`
+	Impact                       float64 `json:"impact"`
+	Favors                       string  `json:"favors"`
+}
+
+type PairwiseExplanation struct {
+	AlternativeA         string                  `json:"alternativeA"`
+	AlternativeB         string                  `json:"alternativeB"`
+	RankA                int                     `json:"rankA"`
+	RankB                int                     `json:"rankB"`
+	ClosenessA           float64                 `json:"closenessA"`
+	ClosenessB           float64                 `json:"closenessB"`
+	PositiveDistanceDiff float64                 `json:"positiveDistanceDiff"`
+	NegativeDistanceDiff float64                 `json:"negativeDistanceDiff"`
+	Contributions        []CriterionContribution `json:"contributions"`
+	Summary              string                  `json:"summary"`
+}
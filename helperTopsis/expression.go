@@ -0,0 +1,351 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// batas ukuran ekspresi agar parser rekursif tidak kehabisan stack
+const (
+	MaxExpressionLength = 4096
+	MaxExpressionDepth  = 64
+)
+
+// Expression adalah ekspresi aritmatika untuk kriteria turunan, misalnya
+// "Harga / Kapasitas" atau "Tes1 + Tes2 + Tes3". Yang didukung hanya angka,
+// nama kolom, + - * /, tanda kurung, dan fungsi min, max, abs, sqrt. Nama
+// kolom yang mengandung spasi ditulis dalam kurung siku: [Lesson Plan].
+type Expression struct {
+	source    string
+	root      exprNode
+	Variables []string
+}
+
+type exprNode interface {
+	eval(values map[string]float64) (float64, error)
+}
+
+type numberNode float64
+
+type variableNode string
+
+type unaryNode struct {
+	operand exprNode
+}
+
+type binaryNode struct {
+	op          byte
+	left, right exprNode
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n numberNode) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+func (n variableNode) eval(values map[string]float64) (float64, error) {
+	value, ok := values[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("missing value %s", string(n))
+	}
+	return value, nil
+}
+
+func (n unaryNode) eval(values map[string]float64) (float64, error) {
+	value, err := n.operand.eval(values)
+	return -value, err
+}
+
+func (n binaryNode) eval(values map[string]float64) (float64, error) {
+	left, err := n.left.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	}
+}
+
+func (n callNode) eval(values map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(values)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+	switch n.name {
+	case "min":
+		result := args[0]
+		for _, value := range args[1:] {
+			result = math.Min(result, value)
+		}
+		return result, nil
+	case "max":
+		result := args[0]
+		for _, value := range args[1:] {
+			result = math.Max(result, value)
+		}
+		return result, nil
+	case "abs":
+		return math.Abs(args[0]), nil
+	default:
+		if args[0] < 0 {
+			return 0, fmt.Errorf("sqrt of negative number")
+		}
+		return math.Sqrt(args[0]), nil
+	}
+}
+
+// jumlah argumen setiap fungsi, -1 berarti minimal satu argumen
+var expressionFunctions = map[string]int{
+	"min":  -1,
+	"max":  -1,
+	"abs":  1,
+	"sqrt": 1,
+}
+
+func ParseExpression(source string) (*Expression, error) {
+	if len(source) > MaxExpressionLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", MaxExpressionLength)
+	}
+	p := &expressionParser{src: source, variables: make(map[string]bool)}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.src[p.pos], p.pos+1)
+	}
+	expr := &Expression{source: source, root: root}
+	for name := range p.variables {
+		expr.Variables = append(expr.Variables, name)
+	}
+	sort.Strings(expr.Variables)
+	return expr, nil
+}
+
+// Evaluate menghitung ekspresi untuk satu alternatif. Hasil NaN atau tak
+// hingga dianggap error.
+func (e *Expression) Evaluate(values map[string]float64) (float64, error) {
+	value, err := e.root.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("result is not a finite number")
+	}
+	return value, nil
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+type expressionParser struct {
+	src       string
+	pos       int
+	depth     int
+	variables map[string]bool
+}
+
+// enter menambah kedalaman rekursi dan menolak ekspresi yang terlalu dalam.
+// Setiap pemanggilan harus diikuti p.depth--.
+func (p *expressionParser) enter() error {
+	p.depth++
+	if p.depth > MaxExpressionDepth {
+		return fmt.Errorf("expression is nested deeper than %d levels", MaxExpressionDepth)
+	}
+	return nil
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *expressionParser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// sum := product (('+' | '-') product)*
+func (p *expressionParser) parseSum() (exprNode, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// product := unary (('*' | '/') unary)*
+func (p *expressionParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// unary := '-' unary | primary
+func (p *expressionParser) parseUnary() (exprNode, error) {
+	if p.peek() == '-' {
+		p.pos++
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// primary := number | name | name '(' args ')' | '[' name ']' | '(' sum ')'
+func (p *expressionParser) parsePrimary() (exprNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case c == '(':
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos+1)
+		}
+		p.pos++
+		return inner, nil
+	case c == '[':
+		end := strings.IndexByte(p.src[p.pos:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("missing ] at position %d", p.pos+1)
+		}
+		name := strings.TrimSpace(p.src[p.pos+1 : p.pos+end])
+		if name == "" {
+			return nil, fmt.Errorf("empty column name at position %d", p.pos+1)
+		}
+		p.pos += end + 1
+		p.variables[name] = true
+		return variableNode(name), nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.src[start:p.pos])
+		}
+		return numberNode(value), nil
+	case isIdentifierRune(p.runeAt(), false):
+		start := p.pos
+		for p.pos < len(p.src) && isIdentifierRune(p.runeAt(), true) {
+			_, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			p.pos += size
+		}
+		name := p.src[start:p.pos]
+		if p.peek() != '(' {
+			p.variables[name] = true
+			return variableNode(name), nil
+		}
+		arity, ok := expressionFunctions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %s", name)
+		}
+		p.pos++
+		var args []exprNode
+		for {
+			arg, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek() == ',' {
+				p.pos++
+				continue
+			}
+			break
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos+1)
+		}
+		p.pos++
+		if arity > 0 && len(args) != arity {
+			return nil, fmt.Errorf("function %s expects %d argument(s)", name, arity)
+		}
+		return callNode{name: name, args: args}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos+1)
+	}
+}
+
+func (p *expressionParser) runeAt() rune {
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return r
+}
+
+// nama kolom boleh memakai huruf non-ASCII, misalnya kualitas_é
+func isIdentifierRune(r rune, inside bool) bool {
+	return r == '_' || unicode.IsLetter(r) || (inside && unicode.IsDigit(r))
+}
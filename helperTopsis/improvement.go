@@ -0,0 +1,40 @@
+package helperTopsis
+
+// ValueBounds membatasi nilai yang boleh diusulkan untuk satu kriteria.
+type ValueBounds struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// ImprovementRequest meminta perubahan nilai terkecil agar Alternative
+// mencapai TargetRank. Kriteria di Fixed tidak boleh diubah.
+type ImprovementRequest struct {
+	TOPSISRequest
+	Alternative string                 `json:"alternative"`
+	TargetRank  int                    `json:"targetRank"`
+	Fixed       []string               `json:"fixed,omitempty"`
+	Bounds      map[string]ValueBounds `json:"bounds,omitempty"`
+}
+
+type ValueChange struct {
+	Criterion string  `json:"criterion"`
+	From      float64 `json:"from"`
+	To        float64 `json:"to"`
+	Delta     float64 `json:"delta"`
+}
+
+// ImprovementSuggestion berisi nilai baru alternatif dan closeness yang
+// dihasilkan. Distance adalah jumlah perubahan relatif terhadap rentang
+// nilai masing-masing kriteria.
+type ImprovementSuggestion struct {
+	Alternative        string             `json:"alternative"`
+	TargetRank         int                `json:"targetRank"`
+	Achieved           bool               `json:"achieved"`
+	OriginalRank       int                `json:"originalRank"`
+	ResultingRank      int                `json:"resultingRank"`
+	OriginalCloseness  float64            `json:"originalCloseness"`
+	ResultingCloseness float64            `json:"resultingCloseness"`
+	Changes            []ValueChange      `json:"changes"`
+	Values             map[string]float64 `json:"values"`
+	Distance           float64            `json:"distance"`
+}
@@ -0,0 +1,158 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"sort"
+)
+
+// kebijakan untuk nilai alternatif yang kosong
+const (
+	MissingReject  = "reject"
+	MissingMean    = "mean"
+	MissingMedian  = "median"
+	MissingMin     = "min"
+	MissingMax     = "max"
+	MissingWorst   = "worst"
+	MissingExclude = "exclude"
+)
+
+// ImputedValue mencatat satu sel yang diisi oleh kebijakan nilai kosong.
+type ImputedValue struct {
+	Alternative string  `json:"alternative"`
+	Criterion   string  `json:"criterion"`
+	Policy      string  `json:"policy"`
+	Value       float64 `json:"value"`
+}
+
+type MissingValueReport struct {
+	Imputed  []ImputedValue `json:"imputed"`
+	Excluded []string       `json:"excluded,omitempty"`
+}
+
+func isMissingPolicy(policy string) bool {
+	switch policy {
+	case "", MissingReject, MissingMean, MissingMedian, MissingMin, MissingMax, MissingWorst, MissingExclude:
+		return true
+	}
+	return false
+}
+
+// withMissingPolicy mengembalikan salinan kriteria dengan kebijakan request
+// dipakai untuk kriteria yang tidak menentukan kebijakannya sendiri.
+func withMissingPolicy(criteria []Criterion, policy string) []Criterion {
+	effective := make([]Criterion, len(criteria))
+	copy(effective, criteria)
+	for i := range effective {
+		if effective[i].MissingPolicy == "" {
+			effective[i].MissingPolicy = policy
+		}
+	}
+	return effective
+}
+
+// ImputeMissingValues menerapkan kebijakan nilai kosong. Alternatif dengan
+// nilai kosong pada kriteria berkebijakan exclude dibuang lebih dulu, lalu
+// sel kosong lainnya diisi dari nilai yang teramati pada alternatif tersisa:
+// mean, median, min, max, atau worst (min untuk benefit, max untuk cost).
+// Request harus sudah lolos ValidateInput. Report bernilai nil jika tidak
+// ada sel yang kosong.
+func ImputeMissingValues(req TOPSISRequest) (TOPSISRequest, *MissingValueReport, error) {
+	criteria := withMissingPolicy(req.Criteria, req.MissingPolicy)
+	var report *MissingValueReport
+
+	kept := make([]Alternative, 0, len(req.Alternatives))
+	for _, alt := range req.Alternatives {
+		excluded := false
+		for _, criterion := range criteria {
+			if criterion.Expression != "" {
+				continue
+			}
+			if _, ok := alt.Values[criterion.Name]; !ok && criterion.MissingPolicy == MissingExclude {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			if report == nil {
+				report = &MissingValueReport{}
+			}
+			report.Excluded = append(report.Excluded, alt.Name)
+			continue
+		}
+		kept = append(kept, alt)
+	}
+	if len(kept) == 0 {
+		req.Alternatives = kept
+		return req, report, nil
+	}
+
+	for _, criterion := range criteria {
+		if criterion.Expression != "" {
+			continue
+		}
+		observed := make([]float64, 0, len(kept))
+		for _, alt := range kept {
+			if value, ok := alt.Values[criterion.Name]; ok {
+				observed = append(observed, value)
+			}
+		}
+		if len(observed) == len(kept) {
+			continue
+		}
+		if len(observed) == 0 {
+			return req, nil, fmt.Errorf("criteria %s has no observed values to impute from", criterion.Name)
+		}
+		value := imputationValue(observed, criterion)
+		for i, alt := range kept {
+			if _, ok := alt.Values[criterion.Name]; ok {
+				continue
+			}
+			values := make(map[string]float64, len(alt.Values)+1)
+			for name, v := range alt.Values {
+				values[name] = v
+			}
+			values[criterion.Name] = value
+			kept[i].Values = values
+			if report == nil {
+				report = &MissingValueReport{}
+			}
+			report.Imputed = append(report.Imputed, ImputedValue{
+				Alternative: alt.Name,
+				Criterion:   criterion.Name,
+				Policy:      criterion.MissingPolicy,
+				Value:       value,
+			})
+		}
+	}
+	req.Alternatives = kept
+	return req, report, nil
+}
+
+func imputationValue(observed []float64, criterion Criterion) float64 {
+	sorted := make([]float64, len(observed))
+	copy(sorted, observed)
+	sort.Float64s(sorted)
+	switch criterion.MissingPolicy {
+	case MissingMedian:
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2
+		}
+		return sorted[middle]
+	case MissingMin:
+		return sorted[0]
+	case MissingMax:
+		return sorted[len(sorted)-1]
+	case MissingWorst:
+		if criterion.Type == Cost {
+			return sorted[len(sorted)-1]
+		}
+		return sorted[0]
+	default:
+		sum := 0.0
+		for _, value := range sorted {
+			sum += value
+		}
+		return sum / float64(len(sorted))
+	}
+}
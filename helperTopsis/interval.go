@@ -0,0 +1,154 @@
+package helperTopsis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// aturan pembanding interval untuk peringkat titik pada IntervalTOPSISRequest.Comparison
+const (
+	// titik tengah interval closeness
+	IntervalMidpoint = "midpoint"
+	// batas bawah interval closeness (pesimis)
+	IntervalLower = "lower"
+	// batas atas interval closeness (optimis)
+	IntervalUpper = "upper"
+	// rata-rata derajat kemungkinan P(A >= B) terhadap semua alternatif lain
+	IntervalPossibility = "possibility"
+)
+
+// Interval adalah nilai rentang [lower, upper], di JSON ditulis sebagai
+// array dua angka, misalnya [12, 15].
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+func (v Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{v.Lower, v.Upper})
+}
+
+func (v *Interval) UnmarshalJSON(data []byte) error {
+	var bounds []float64
+	if err := json.Unmarshal(data, &bounds); err != nil || len(bounds) != 2 {
+		return fmt.Errorf("interval must be an array of two numbers")
+	}
+	v.Lower, v.Upper = bounds[0], bounds[1]
+	return nil
+}
+
+func (v Interval) Midpoint() float64 {
+	return (v.Lower + v.Upper) / 2
+}
+
+func (v Interval) Validate() error {
+	if v.Lower > v.Upper {
+		return fmt.Errorf("interval lower bound %g is greater than upper bound %g", v.Lower, v.Upper)
+	}
+	return nil
+}
+
+// Possibility adalah derajat kemungkinan v >= other, bernilai 0 sampai 1.
+func (v Interval) Possibility(other Interval) float64 {
+	width := (v.Upper - v.Lower) + (other.Upper - other.Lower)
+	if width == 0 {
+		switch {
+		case v.Lower > other.Lower:
+			return 1
+		case v.Lower == other.Lower:
+			return 0.5
+		default:
+			return 0
+		}
+	}
+	return math.Max(0, math.Min(1, (v.Upper-other.Lower)/width))
+}
+
+// squaredDistanceRange mengembalikan kuadrat jarak terdekat dan terjauh dari
+// titik ke interval.
+func (v Interval) squaredDistanceRange(point float64) (float64, float64) {
+	low, high := math.Abs(v.Lower-point), math.Abs(v.Upper-point)
+	far := math.Max(low, high)
+	if point >= v.Lower && point <= v.Upper {
+		return 0, far * far
+	}
+	near := math.Min(low, high)
+	return near * near, far * far
+}
+
+func isIntervalComparison(rule string) bool {
+	switch rule {
+	case "", IntervalMidpoint, IntervalLower, IntervalUpper, IntervalPossibility:
+		return true
+	}
+	return false
+}
+
+type IntervalTOPSISRequest struct {
+	TOPSISRequest
+	// aturan peringkat titik, lihat konstanta Interval*; default midpoint
+	Comparison string `json:"comparison,omitempty"`
+}
+
+// IntervalTOPSISResult berisi interval jarak dan closeness. Score adalah
+// nilai titik menurut aturan pembanding yang dipakai untuk Rank.
+type IntervalTOPSISResult struct {
+	Name             string              `json:"name"`
+	Closeness        Interval            `json:"closeness"`
+	PositiveDistance Interval            `json:"positiveDistance"`
+	NegativeDistance Interval            `json:"negativeDistance"`
+	Score            float64             `json:"score"`
+	Rank             int                 `json:"rank"`
+	WeightedValues   map[string]Interval `json:"weightedValues"`
+}
+
+type IntervalTOPSISResponse struct {
+	Comparison           string                 `json:"comparison"`
+	Results              []IntervalTOPSISResult `json:"results"`
+	IdealPositive        map[string]float64     `json:"idealPositive"`
+	IdealNegative        map[string]float64     `json:"idealNegative"`
+	NormalizationFactors map[string]float64     `json:"normalizationFactors"`
+	Rejected             []RejectedAlternative  `json:"rejected,omitempty"`
+}
+
+func ValidateIntervalRequest(req IntervalTOPSISRequest) error {
+	if !isIntervalComparison(req.Comparison) {
+		return fmt.Errorf("Invalid interval comparison : %s", req.Comparison)
+	}
+	if req.Normalization != "" && req.Normalization != NormalizationVector {
+		return fmt.Errorf("normalization %s is not supported in interval mode", req.Normalization)
+	}
+	if req.Distance != "" && req.Distance != DistanceEuclidean {
+		return fmt.Errorf("distance %s is not supported in interval mode", req.Distance)
+	}
+	return nil
+}
+
+// IntervalValues mengembalikan nilai setiap kriteria sebagai interval, nilai
+// titik menjadi interval selebar nol.
+func IntervalValues(alt Alternative, criteria []Criterion) map[string]Interval {
+	values := make(map[string]Interval, len(criteria))
+	for _, criterion := range criteria {
+		if interval, ok := alt.Intervals[criterion.Name]; ok {
+			values[criterion.Name] = interval
+			continue
+		}
+		value := alt.Values[criterion.Name]
+		values[criterion.Name] = Interval{Lower: value, Upper: value}
+	}
+	return values
+}
+
+// IntervalSeparation menghitung interval jarak ke satu titik ideal: batas
+// bawah memakai ujung interval terdekat di setiap kriteria, batas atas
+// memakai ujung terjauh.
+func IntervalSeparation(weighted map[string]Interval, ideal map[string]float64) Interval {
+	nearSum, farSum := 0.0, 0.0
+	for name, value := range weighted {
+		near, far := value.squaredDistanceRange(ideal[name])
+		nearSum += near
+		farSum += far
+	}
+	return Interval{Lower: math.Sqrt(nearSum), Upper: math.Sqrt(farSum)}
+}
@@ -0,0 +1,155 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+)
+
+const ifnTolerance = 1e-9
+
+// IFN adalah bilangan fuzzy intuisionistik: derajat keanggotaan (mu) dan
+// non-keanggotaan (nu) dengan mu + nu <= 1. Sisanya adalah derajat keraguan.
+type IFN struct {
+	Membership    float64 `json:"mu"`
+	NonMembership float64 `json:"nu"`
+}
+
+func (v IFN) Hesitation() float64 {
+	return math.Max(1-v.Membership-v.NonMembership, 0)
+}
+
+// Score adalah mu - nu, dipakai sebagai nilai tunggal pada TOPSISResult.
+func (v IFN) Score() float64 {
+	return v.Membership - v.NonMembership
+}
+
+func (v IFN) Validate() error {
+	if v.Membership < 0 || v.NonMembership < 0 || v.Membership > 1 || v.NonMembership > 1 {
+		return fmt.Errorf("mu and nu must be between 0 and 1")
+	}
+	if v.Membership+v.NonMembership > 1+ifnTolerance {
+		return fmt.Errorf("mu + nu must not exceed 1")
+	}
+	return nil
+}
+
+// Multiply adalah perkalian IFN, dipakai untuk memberi bobot IFN pada nilai.
+func (v IFN) Multiply(w IFN) IFN {
+	return IFN{
+		Membership:    v.Membership * w.Membership,
+		NonMembership: v.NonMembership + w.NonMembership - v.NonMembership*w.NonMembership,
+	}
+}
+
+// IFWA (intuitionistic fuzzy weighted averaging) menggabungkan beberapa IFN
+// dengan bobot crisp berjumlah 1: mu = 1 - Π(1-mu_k)^w_k, nu = Π nu_k^w_k.
+func IFWA(values []IFN, weights []float64) IFN {
+	nonMembershipComplement := 1.0
+	nonMembership := 1.0
+	for k, value := range values {
+		nonMembershipComplement *= math.Pow(1-value.Membership, weights[k])
+		nonMembership *= math.Pow(value.NonMembership, weights[k])
+	}
+	return IFN{Membership: 1 - nonMembershipComplement, NonMembership: nonMembership}
+}
+
+// IFNDistance adalah jarak Euclidean ternormalisasi Szmidt-Kacprzyk antara
+// dua vektor IFN berurutan sesuai criteria.
+func IFNDistance(a, b map[string]IFN, criteria []IFCriterion) float64 {
+	sum := 0.0
+	for _, criterion := range criteria {
+		x, y := a[criterion.Name], b[criterion.Name]
+		sum += math.Pow(x.Membership-y.Membership, 2) +
+			math.Pow(x.NonMembership-y.NonMembership, 2) +
+			math.Pow(x.Hesitation()-y.Hesitation(), 2)
+	}
+	return math.Sqrt(sum / float64(2*len(criteria)))
+}
+
+// IFCriterion adalah kriteria dengan bobot IFN, misalnya bobot "penting"
+// {mu: 0.75, nu: 0.2}.
+type IFCriterion struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Weight IFN    `json:"weight"`
+}
+
+type IFAlternative struct {
+	Name   string         `json:"name"`
+	Values map[string]IFN `json:"values"`
+}
+
+// IFExpert adalah penilaian satu panelis. Weight adalah bobot crisp panelis,
+// jika semua panelis berbobot 0 maka bobotnya dianggap sama.
+type IFExpert struct {
+	Name         string          `json:"name"`
+	Weight       float64         `json:"weight,omitempty"`
+	Alternatives []IFAlternative `json:"alternatives"`
+}
+
+type IFTOPSISRequest struct {
+	Criteria []IFCriterion `json:"criteria"`
+	Experts  []IFExpert    `json:"experts"`
+}
+
+// IFTOPSISResponse memakai bentuk TOPSISResult biasa. NormalizedValues berisi
+// skor (mu - nu) penilaian gabungan, WeightedValues berisi skor setelah
+// dikalikan bobot IFN. Nilai IFN lengkap ada di Aggregated dan Weighted.
+type IFTOPSISResponse struct {
+	Results       []TOPSISResult            `json:"results"`
+	IdealPositive map[string]IFN            `json:"idealPositive"`
+	IdealNegative map[string]IFN            `json:"idealNegative"`
+	ExpertWeights map[string]float64        `json:"expertWeights"`
+	Aggregated    map[string]map[string]IFN `json:"aggregated"`
+	Weighted      map[string]map[string]IFN `json:"weighted"`
+}
+
+func ValidateIFTOPSISRequest(req IFTOPSISRequest) error {
+	if len(req.Criteria) == 0 {
+		return fmt.Errorf("No criteria Provided")
+	}
+	if len(req.Experts) == 0 {
+		return fmt.Errorf("No experts Provided")
+	}
+	for _, criterion := range req.Criteria {
+		if criterion.Type != Cost && criterion.Type != Benefit {
+			return fmt.Errorf("Invalid Criterion Type for %s : %s", criterion.Name, criterion.Type)
+		}
+		if err := criterion.Weight.Validate(); err != nil {
+			return fmt.Errorf("criterion %s weight: %w", criterion.Name, err)
+		}
+	}
+	if len(req.Experts[0].Alternatives) == 0 {
+		return fmt.Errorf("No Alternative Provided")
+	}
+	for _, expert := range req.Experts {
+		if expert.Weight < 0 {
+			return fmt.Errorf("expert %s has negative weight", expert.Name)
+		}
+		if len(expert.Alternatives) != len(req.Experts[0].Alternatives) {
+			return fmt.Errorf("expert %s rates %d alternatives, expected %d",
+				expert.Name, len(expert.Alternatives), len(req.Experts[0].Alternatives))
+		}
+		rated := make(map[string]bool, len(expert.Alternatives))
+		for _, alt := range expert.Alternatives {
+			rated[alt.Name] = true
+			for _, criterion := range req.Criteria {
+				value, ok := alt.Values[criterion.Name]
+				if !ok {
+					return fmt.Errorf("expert %s: Alternative %s is missing Value for criteria %s",
+						expert.Name, alt.Name, criterion.Name)
+				}
+				if err := value.Validate(); err != nil {
+					return fmt.Errorf("expert %s: Alternative %s, criteria %s: %w",
+						expert.Name, alt.Name, criterion.Name, err)
+				}
+			}
+		}
+		for _, alt := range req.Experts[0].Alternatives {
+			if !rated[alt.Name] {
+				return fmt.Errorf("expert %s is missing alternative %s", expert.Name, alt.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package helperTopsis
+
+// InverseWeightRequest meminta pencarian bobot yang membuat Alternative
+// berada di peringkat 1. Samples adalah jumlah titik acak pada simplex bobot.
+type InverseWeightRequest struct {
+	TOPSISRequest
+	Alternative string `json:"alternative"`
+	Samples     int    `json:"samples,omitempty"`
+	Seed        int64  `json:"seed,omitempty"`
+}
+
+type WeightRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// InverseWeightResult melaporkan apakah ada bobot yang memenangkan
+// Alternative. FeasibleShare adalah perkiraan porsi simplex bobot yang
+// memenangkannya, WeightBounds adalah rentang tiap bobot pada wilayah itu.
+type InverseWeightResult struct {
+	Alternative      string                 `json:"alternative"`
+	CurrentRank      int                    `json:"currentRank"`
+	Feasible         bool                   `json:"feasible"`
+	FeasibleShare    float64                `json:"feasibleShare"`
+	SamplesEvaluated int                    `json:"samplesEvaluated"`
+	CurrentWeights   map[string]float64     `json:"currentWeights"`
+	ClosestWeights   map[string]float64     `json:"closestWeights,omitempty"`
+	Distance         float64                `json:"distance"`
+	WeightBounds     map[string]WeightRange `json:"weightBounds,omitempty"`
+}
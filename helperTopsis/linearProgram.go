@@ -0,0 +1,179 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+)
+
+const simplexEpsilon = 1e-9
+
+// LinearProgram adalah model minimize Objective·x dengan x >= 0 dan setiap
+// baris Constraints[i]·x <= Bounds[i], atau = Bounds[i] jika Equal[i].
+// Semua Bounds harus tidak negatif.
+type LinearProgram struct {
+	Objective   []float64
+	Constraints [][]float64
+	Bounds      []float64
+	Equal       []bool
+}
+
+// SolveLinearProgram menyelesaikan LinearProgram dengan simplex dua fase dan
+// aturan Bland supaya tidak berputar pada basis yang degenerate. Hasilnya
+// adalah x optimal dan nilai objektifnya.
+func SolveLinearProgram(lp LinearProgram) ([]float64, float64, error) {
+	n := len(lp.Objective)
+	m := len(lp.Constraints)
+	artificialCount := 0
+	for i := 0; i < m; i++ {
+		if lp.Bounds[i] < 0 {
+			return nil, 0, fmt.Errorf("linear program bound %d is negative", i)
+		}
+		if lp.Equal[i] {
+			artificialCount++
+		}
+	}
+	slackStart := n
+	artificialStart := n + m
+	columns := n + m + artificialCount
+	rhs := columns
+
+	tableau := make([][]float64, m+1)
+	basis := make([]int, m)
+	artificial := artificialStart
+	for i := 0; i < m; i++ {
+		tableau[i] = make([]float64, columns+1)
+		copy(tableau[i], lp.Constraints[i])
+		tableau[i][rhs] = lp.Bounds[i]
+		if lp.Equal[i] {
+			tableau[i][artificial] = 1
+			basis[i] = artificial
+			artificial++
+		} else {
+			tableau[i][slackStart+i] = 1
+			basis[i] = slackStart + i
+		}
+	}
+	objective := make([]float64, columns+1)
+	tableau[m] = objective
+	allowed := make([]bool, columns)
+	for j := range allowed {
+		allowed[j] = true
+	}
+
+	if artificialCount > 0 {
+		// fase 1: minimalkan jumlah variabel artifisial
+		for i := 0; i < m; i++ {
+			if basis[i] >= artificialStart {
+				for j := 0; j <= columns; j++ {
+					if j < artificialStart || j == rhs {
+						objective[j] -= tableau[i][j]
+					}
+				}
+			}
+		}
+		if err := runSimplex(tableau, basis, allowed); err != nil {
+			return nil, 0, err
+		}
+		if -objective[rhs] > 1e-7 {
+			return nil, 0, fmt.Errorf("linear program is infeasible")
+		}
+		for j := artificialStart; j < columns; j++ {
+			allowed[j] = false
+		}
+		// keluarkan variabel artifisial yang masih basis dengan nilai 0
+		for i := 0; i < m; i++ {
+			if basis[i] < artificialStart {
+				continue
+			}
+			for j := 0; j < artificialStart; j++ {
+				if math.Abs(tableau[i][j]) > simplexEpsilon {
+					pivot(tableau, basis, i, j)
+					break
+				}
+			}
+		}
+	}
+
+	// fase 2: fungsi objektif asli dalam bentuk biaya tereduksi
+	for j := range objective {
+		objective[j] = 0
+	}
+	copy(objective, lp.Objective)
+	for i := 0; i < m; i++ {
+		if cost := costOf(lp.Objective, basis[i]); cost != 0 {
+			for j := 0; j <= columns; j++ {
+				objective[j] -= cost * tableau[i][j]
+			}
+		}
+	}
+	if err := runSimplex(tableau, basis, allowed); err != nil {
+		return nil, 0, err
+	}
+
+	x := make([]float64, n)
+	for i, variable := range basis {
+		if variable < n {
+			x[variable] = tableau[i][rhs]
+		}
+	}
+	return x, -objective[rhs], nil
+}
+
+func costOf(objective []float64, variable int) float64 {
+	if variable < len(objective) {
+		return objective[variable]
+	}
+	return 0
+}
+
+func runSimplex(tableau [][]float64, basis []int, allowed []bool) error {
+	m := len(basis)
+	objective := tableau[m]
+	rhs := len(objective) - 1
+	for {
+		entering := -1
+		for j := 0; j < rhs; j++ {
+			if allowed[j] && objective[j] < -simplexEpsilon {
+				entering = j
+				break
+			}
+		}
+		if entering < 0 {
+			return nil
+		}
+		leaving := -1
+		bestRatio := math.Inf(1)
+		for i := 0; i < m; i++ {
+			if tableau[i][entering] <= simplexEpsilon {
+				continue
+			}
+			ratio := tableau[i][rhs] / tableau[i][entering]
+			if ratio < bestRatio-simplexEpsilon ||
+				(math.Abs(ratio-bestRatio) <= simplexEpsilon && basis[i] < basis[leaving]) {
+				bestRatio = ratio
+				leaving = i
+			}
+		}
+		if leaving < 0 {
+			return fmt.Errorf("linear program is unbounded")
+		}
+		pivot(tableau, basis, leaving, entering)
+	}
+}
+
+func pivot(tableau [][]float64, basis []int, row, column int) {
+	divisor := tableau[row][column]
+	for j := range tableau[row] {
+		tableau[row][j] /= divisor
+	}
+	for i := range tableau {
+		if i == row || tableau[i][column] == 0 {
+			continue
+		}
+		factor := tableau[i][column]
+		for j := range tableau[i] {
+			tableau[i][j] -= factor * tableau[row][j]
+		}
+	}
+	basis[row] = column
+}
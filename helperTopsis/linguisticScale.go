@@ -0,0 +1,93 @@
+package helperTopsis
+
+import "fmt"
+
+// LinguisticScale memetakan label rubrik ke angka, misalnya
+// {"Kurang": 1, "Cukup": 2, "Baik": 3, "Sangat Baik": 4}.
+type LinguisticScale struct {
+	Name   string             `json:"name,omitempty"`
+	Labels map[string]float64 `json:"labels"`
+}
+
+func validateLabels(alt Alternative, criteria []Criterion) error {
+	scales := make(map[string]*LinguisticScale, len(criteria))
+	for _, criterion := range criteria {
+		scales[criterion.Name] = criterion.Scale
+	}
+	for criterionName, label := range alt.Labels {
+		scale, known := scales[criterionName]
+		if !known {
+			continue
+		}
+		if scale == nil {
+			return fmt.Errorf(
+				"Alternative %s uses label %q for criteria %s which has no scale",
+				alt.Name, label, criterionName,
+			)
+		}
+		if _, ok := scale.Labels[label]; !ok {
+			return fmt.Errorf(
+				"Alternative %s has unknown label %q for criteria %s",
+				alt.Name, label, criterionName,
+			)
+		}
+	}
+	return nil
+}
+
+// ApplyScales mengembalikan salinan request dengan setiap label alternatif
+// diganti nilai numeriknya. Request harus sudah lolos ValidateInput.
+func ApplyScales(req TOPSISRequest) TOPSISRequest {
+	scales := make(map[string]*LinguisticScale, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		scales[criterion.Name] = criterion.Scale
+	}
+	alternatives := make([]Alternative, len(req.Alternatives))
+	for i, alt := range req.Alternatives {
+		alternatives[i] = alt
+		if len(alt.Labels) == 0 {
+			continue
+		}
+		values := make(map[string]float64, len(alt.Values)+len(alt.Labels))
+		for name, value := range alt.Values {
+			values[name] = value
+		}
+		for name, label := range alt.Labels {
+			if scale := scales[name]; scale != nil {
+				values[name] = scale.Labels[label]
+			}
+		}
+		alternatives[i].Values = values
+	}
+	req.Alternatives = alternatives
+	return req
+}
+
+// LabelResults menyalin label linguistik dan nilai numeriknya ke hasil
+// TOPSIS agar response menampilkan keduanya.
+func LabelResults(results []TOPSISResult, alternatives []Alternative, criteria []Criterion) {
+	scales := make(map[string]*LinguisticScale, len(criteria))
+	for _, criterion := range criteria {
+		scales[criterion.Name] = criterion.Scale
+	}
+	labels := make(map[string]map[string]string, len(alternatives))
+	for _, alt := range alternatives {
+		if len(alt.Labels) > 0 {
+			labels[alt.Name] = alt.Labels
+		}
+	}
+	for i := range results {
+		altLabels, ok := labels[results[i].Name]
+		if !ok {
+			continue
+		}
+		results[i].Labels = make(map[string]string, len(altLabels))
+		results[i].LabelValues = make(map[string]float64, len(altLabels))
+		for name, label := range altLabels {
+			if scale := scales[name]; scale != nil {
+				results[i].Labels[name] = label
+				results[i].LabelValues[name] = scale.Labels[label]
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+)
+
+// metode jarak pada TOPSISRequest.Distance
+const (
+	DistanceEuclidean   = "euclidean"
+	DistanceMahalanobis = "mahalanobis"
+)
+
+const WarningSingularCovariance = "singular_covariance"
+
+// batas pivot relatif terhadap diagonal terbesar, di bawahnya kovarians
+// dianggap singular
+const singularTolerance = 1e-10
+
+func isDistance(method string) bool {
+	return method == "" || method == DistanceEuclidean || method == DistanceMahalanobis
+}
+
+func validateCovariance(covariance map[string]map[string]float64, criteria []Criterion) error {
+	for _, row := range criteria {
+		if _, ok := covariance[row.Name]; !ok {
+			return fmt.Errorf("covariance is missing row for criteria %s", row.Name)
+		}
+		for _, column := range criteria {
+			value, ok := covariance[row.Name][column.Name]
+			if !ok {
+				return fmt.Errorf("covariance is missing entry %s/%s", row.Name, column.Name)
+			}
+			if math.Abs(value-covariance[column.Name][row.Name]) > 1e-9 {
+				return fmt.Errorf("covariance is not symmetric at %s/%s", row.Name, column.Name)
+			}
+		}
+		if covariance[row.Name][row.Name] < 0 {
+			return fmt.Errorf("covariance has negative variance for criteria %s", row.Name)
+		}
+	}
+	if !IsPositiveDefinite(CovarianceMatrix(covariance, criteria)) {
+		return fmt.Errorf("covariance is not positive-definite")
+	}
+	return nil
+}
+
+// IsPositiveDefinite mencoba dekomposisi Cholesky matriks simetris. Pivot
+// yang tidak lebih besar dari singularTolerance dikali diagonal terbesar
+// berarti matriks tidak definit positif, termasuk matriks yang singular.
+func IsPositiveDefinite(matrix [][]float64) bool {
+	n := len(matrix)
+	scale := 0.0
+	for i := range matrix {
+		scale = math.Max(scale, math.Abs(matrix[i][i]))
+	}
+	if n == 0 || scale == 0 {
+		return false
+	}
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+	}
+	for j := 0; j < n; j++ {
+		pivot := matrix[j][j]
+		for k := 0; k < j; k++ {
+			pivot -= lower[j][k] * lower[j][k]
+		}
+		if pivot <= singularTolerance*scale {
+			return false
+		}
+		lower[j][j] = math.Sqrt(pivot)
+		for i := j + 1; i < n; i++ {
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+			lower[i][j] = sum / lower[j][j]
+		}
+	}
+	return true
+}
+
+// EstimateCovariance menghitung kovarians sampel (pembagi n-1) dari matriks
+// ternormalisasi, berurutan sesuai criteria.
+func EstimateCovariance(normalizedMatrix map[string]map[string]float64, criteria []Criterion) [][]float64 {
+	m := len(criteria)
+	covariance := make([][]float64, m)
+	for i := range covariance {
+		covariance[i] = make([]float64, m)
+	}
+	n := len(normalizedMatrix)
+	if n < 2 {
+		return covariance
+	}
+	means := make([]float64, m)
+	for _, values := range normalizedMatrix {
+		for i, criterion := range criteria {
+			means[i] += values[criterion.Name] / float64(n)
+		}
+	}
+	for _, values := range normalizedMatrix {
+		for i, a := range criteria {
+			for j, b := range criteria {
+				covariance[i][j] += (values[a.Name] - means[i]) * (values[b.Name] - means[j]) / float64(n-1)
+			}
+		}
+	}
+	return covariance
+}
+
+// CovarianceMatrix mengubah kovarians berbentuk map menjadi matriks
+// berurutan sesuai criteria.
+func CovarianceMatrix(covariance map[string]map[string]float64, criteria []Criterion) [][]float64 {
+	matrix := make([][]float64, len(criteria))
+	for i, a := range criteria {
+		matrix[i] = make([]float64, len(criteria))
+		for j, b := range criteria {
+			matrix[i][j] = covariance[a.Name][b.Name]
+		}
+	}
+	return matrix
+}
+
+// InvertMatrix membalik matriks dengan eliminasi Gauss-Jordan dan pivot
+// parsial. Nilai kembalian kedua false jika matriks singular.
+func InvertMatrix(matrix [][]float64) ([][]float64, bool) {
+	n := len(matrix)
+	scale := 0.0
+	for i := range matrix {
+		scale = math.Max(scale, math.Abs(matrix[i][i]))
+	}
+	if n == 0 || scale == 0 {
+		return nil, false
+	}
+	a := make([][]float64, n)
+	inverse := make([][]float64, n)
+	for i := range matrix {
+		a[i] = append([]float64(nil), matrix[i]...)
+		inverse[i] = make([]float64, n)
+		inverse[i][i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) <= singularTolerance*scale {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+		divisor := a[col][col]
+		for k := 0; k < n; k++ {
+			a[col][k] /= divisor
+			inverse[col][k] /= divisor
+		}
+		for row := 0; row < n; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for k := 0; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+				inverse[row][k] -= factor * inverse[col][k]
+			}
+		}
+	}
+	return inverse, true
+}
+
+// CalculateMahalanobisSeparation menghitung jarak ke solusi ideal dengan
+// d² = Δᵀ W Σ⁻¹ W Δ, Δ adalah selisih nilai ternormalisasi dan W matriks
+// diagonal bobot. Karena WΔ sama dengan selisih pada matriks terbobot, jarak
+// dihitung langsung dari weightedMatrix dengan inverseCovariance = Σ⁻¹ dari
+// matriks ternormalisasi. Σ sengaja tidak diambil dari matriks terbobot
+// karena bobotnya akan saling menghapus. Σ harus definit positif, jadi
+// math.Max hanya meredam galat pembulatan di sekitar nol.
+func CalculateMahalanobisSeparation(
+	weightedMatrix map[string]map[string]float64,
+	idealPositive, idealNegative map[string]float64,
+	criteria []Criterion,
+	inverseCovariance [][]float64,
+) (map[string]float64, map[string]float64) {
+	positiveDistance := make(map[string]float64)
+	negativeDistance := make(map[string]float64)
+	m := len(criteria)
+	positiveDiff := make([]float64, m)
+	negativeDiff := make([]float64, m)
+	for altName, weightedValues := range weightedMatrix {
+		for i, criterion := range criteria {
+			positiveDiff[i] = weightedValues[criterion.Name] - idealPositive[criterion.Name]
+			negativeDiff[i] = weightedValues[criterion.Name] - idealNegative[criterion.Name]
+		}
+		positiveDistance[altName] = math.Sqrt(math.Max(quadraticForm(positiveDiff, inverseCovariance), 0))
+		negativeDistance[altName] = math.Sqrt(math.Max(quadraticForm(negativeDiff, inverseCovariance), 0))
+	}
+	return positiveDistance, negativeDistance
+}
+
+func quadraticForm(x []float64, matrix [][]float64) float64 {
+	sum := 0.0
+	for i := range x {
+		for j := range x {
+			sum += x[i] * matrix[i][j] * x[j]
+		}
+	}
+	return sum
+}
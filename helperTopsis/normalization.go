@@ -0,0 +1,163 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+)
+
+// metode normalisasi pada TOPSISRequest.Normalization
+const (
+	// normalisasi vektor biasa, hanya bermakna untuk nilai tidak negatif
+	NormalizationVector = "vector"
+	// geser kriteria yang punya nilai negatif agar minimumnya 0, lalu vektor
+	NormalizationShift = "shift"
+	// (x - min) / (max - min), aman untuk nilai negatif
+	NormalizationMinMax = "minmax"
+)
+
+// kode peringatan pada TOPSISResponse.Warnings
+const (
+	WarningZeroVariance   = "zero_variance"
+	WarningZeroFactor     = "zero_factor"
+	WarningNegativeValues = "negative_values"
+	WarningShifted        = "shifted"
+)
+
+// CalculationWarning menandai hal yang perlu diperhatikan pada perhitungan,
+// misalnya kriteria yang semua alternatifnya bernilai sama. Criterion kosong
+// jika peringatan berlaku untuk seluruh matriks.
+type CalculationWarning struct {
+	Criterion string `json:"criterion,omitempty"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+}
+
+func isNormalization(method string) bool {
+	switch method {
+	case "", NormalizationVector, NormalizationShift, NormalizationMinMax:
+		return true
+	}
+	return false
+}
+
+// Normalize menormalisasi matriks keputusan sesuai req.Normalization dan
+// mengembalikan faktor normalisasi (rentang max-min untuk minmax), matriks
+// ternormalisasi, serta peringatan per kriteria. Tanpa alternatif semua
+// faktornya 0 dan matriksnya kosong.
+func Normalize(req TOPSISRequest) (map[string]float64, map[string]map[string]float64, []CalculationWarning) {
+	var warnings []CalculationWarning
+	if len(req.Alternatives) == 0 {
+		factors := make(map[string]float64, len(req.Criteria))
+		for _, criterion := range req.Criteria {
+			factors[criterion.Name] = 0
+		}
+		return factors, map[string]map[string]float64{}, nil
+	}
+	low, high := valueRanges(req)
+
+	if req.Normalization == NormalizationMinMax {
+		factors := make(map[string]float64, len(req.Criteria))
+		normalized := make(map[string]map[string]float64, len(req.Alternatives))
+		for _, alt := range req.Alternatives {
+			normalized[alt.Name] = make(map[string]float64, len(req.Criteria))
+			for _, criterion := range req.Criteria {
+				spread := high[criterion.Name] - low[criterion.Name]
+				if spread > 0 {
+					normalized[alt.Name][criterion.Name] = (alt.Values[criterion.Name] - low[criterion.Name]) / spread
+				} else {
+					normalized[alt.Name][criterion.Name] = 0
+				}
+			}
+		}
+		for _, criterion := range req.Criteria {
+			factors[criterion.Name] = high[criterion.Name] - low[criterion.Name]
+			if factors[criterion.Name] == 0 {
+				warnings = append(warnings, zeroVarianceWarning(criterion.Name, low[criterion.Name]))
+			}
+		}
+		return factors, normalized, warnings
+	}
+
+	shifts := make(map[string]float64)
+	if req.Normalization == NormalizationShift {
+		for _, criterion := range req.Criteria {
+			if low[criterion.Name] < 0 {
+				shifts[criterion.Name] = -low[criterion.Name]
+				warnings = append(warnings, CalculationWarning{
+					Criterion: criterion.Name,
+					Code:      WarningShifted,
+					Message:   fmt.Sprintf("values shifted by %g so that the minimum is 0", -low[criterion.Name]),
+				})
+			}
+		}
+		if len(shifts) > 0 {
+			alternatives := make([]Alternative, len(req.Alternatives))
+			for i, alt := range req.Alternatives {
+				alternatives[i] = alt
+				values := make(map[string]float64, len(alt.Values))
+				for name, value := range alt.Values {
+					values[name] = value + shifts[name]
+				}
+				alternatives[i].Values = values
+			}
+			req.Alternatives = alternatives
+		}
+	}
+
+	factors := CalculateNormalizationFactors(req)
+	// nilai yang sudah digeser tidak lagi negatif
+	for name, shift := range shifts {
+		low[name] += shift
+		high[name] += shift
+	}
+	warnings = append(warnings, VectorNormalizationWarnings(req.Criteria, factors, low, high)...)
+	return factors, NormalizeDecisionatrix(req, factors), warnings
+}
+
+// VectorNormalizationWarnings memeriksa hasil normalisasi vektor dari faktor
+// normalisasi serta nilai terkecil dan terbesar setiap kriteria. Dipakai
+// juga oleh mode streaming yang tidak menyimpan seluruh matriks.
+func VectorNormalizationWarnings(criteria []Criterion, factors, low, high map[string]float64) []CalculationWarning {
+	var warnings []CalculationWarning
+	for _, criterion := range criteria {
+		switch {
+		case factors[criterion.Name] == 0:
+			warnings = append(warnings, CalculationWarning{
+				Criterion: criterion.Name,
+				Code:      WarningZeroFactor,
+				Message:   "normalization factor is 0, all normalized values are set to 0",
+			})
+		case high[criterion.Name] == low[criterion.Name]:
+			warnings = append(warnings, zeroVarianceWarning(criterion.Name, low[criterion.Name]))
+		}
+		if low[criterion.Name] < 0 {
+			warnings = append(warnings, CalculationWarning{
+				Criterion: criterion.Name,
+				Code:      WarningNegativeValues,
+				Message:   "vector normalization is not meaningful for negative values, use shift or minmax",
+			})
+		}
+	}
+	return warnings
+}
+
+func zeroVarianceWarning(criterion string, value float64) CalculationWarning {
+	return CalculationWarning{
+		Criterion: criterion,
+		Code:      WarningZeroVariance,
+		Message:   fmt.Sprintf("all alternatives have the same value (%g), the criterion does not affect the ranking", value),
+	}
+}
+
+func valueRanges(req TOPSISRequest) (map[string]float64, map[string]float64) {
+	low := make(map[string]float64, len(req.Criteria))
+	high := make(map[string]float64, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		low[criterion.Name], high[criterion.Name] = math.Inf(1), math.Inf(-1)
+		for _, alt := range req.Alternatives {
+			low[criterion.Name] = math.Min(low[criterion.Name], alt.Values[criterion.Name])
+			high[criterion.Name] = math.Max(high[criterion.Name], alt.Values[criterion.Name])
+		}
+	}
+	return low, high
+}
@@ -0,0 +1,97 @@
+package helperTopsis
+
+import "fmt"
+
+// Quota membatasi jumlah alternatif terpilih dari satu kategori.
+// Max 0 berarti tanpa batas atas.
+type Quota struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+// PortfolioRequest memilih beberapa alternatif sekaligus dengan total
+// closeness terbesar tanpa melewati Budget. Biaya setiap alternatif dibaca
+// dari Values[CostAttribute] setelah konversi satuan, kebijakan nilai kosong
+// dan kriteria turunan, jadi atribut ini sebaiknya kolom Helper jika tidak
+// ikut diranking. MaxCount 0 berarti tanpa batas atas.
+type PortfolioRequest struct {
+	TOPSISRequest
+	CostAttribute string  `json:"costAttribute"`
+	Budget        float64 `json:"budget"`
+	MinCount      int     `json:"minCount,omitempty"`
+	MaxCount      int     `json:"maxCount,omitempty"`
+	// kategori per nama alternatif, dipakai oleh Quotas
+	Categories map[string]string `json:"categories,omitempty"`
+	Quotas     map[string]Quota  `json:"quotas,omitempty"`
+}
+
+type PortfolioItem struct {
+	Name      string  `json:"name"`
+	Closeness float64 `json:"closeness"`
+	Rank      int     `json:"rank"`
+	Cost      float64 `json:"cost"`
+	Category  string  `json:"category,omitempty"`
+}
+
+// PortfolioResult berisi himpunan terpilih dan alternatif dengan closeness
+// tertinggi yang tidak terpilih.
+type PortfolioResult struct {
+	Selected       []PortfolioItem       `json:"selected"`
+	TotalCloseness float64               `json:"totalCloseness"`
+	TotalCost      float64               `json:"totalCost"`
+	Budget         float64               `json:"budget"`
+	Leftover       float64               `json:"leftover"`
+	BestExcluded   *PortfolioItem        `json:"bestExcluded,omitempty"`
+	Rejected       []RejectedAlternative `json:"rejected,omitempty"`
+	NodesExplored  int                   `json:"nodesExplored"`
+}
+
+func ValidatePortfolioRequest(req PortfolioRequest) error {
+	if req.CostAttribute == "" {
+		return fmt.Errorf("costAttribute must be provided")
+	}
+	if req.Budget < 0 {
+		return fmt.Errorf("budget must not be negative")
+	}
+	if req.MinCount < 0 || req.MaxCount < 0 {
+		return fmt.Errorf("minCount and maxCount must not be negative")
+	}
+	if req.MaxCount > 0 && req.MinCount > req.MaxCount {
+		return fmt.Errorf("minCount %d is greater than maxCount %d", req.MinCount, req.MaxCount)
+	}
+	names := make(map[string]bool, len(req.Alternatives))
+	for _, alt := range req.Alternatives {
+		names[alt.Name] = true
+	}
+	for name := range req.Categories {
+		if !names[name] {
+			return fmt.Errorf("category given for unknown alternative %s", name)
+		}
+	}
+	for category, quota := range req.Quotas {
+		if quota.Min < 0 || quota.Max < 0 {
+			return fmt.Errorf("quota for %s must not be negative", category)
+		}
+		if quota.Max > 0 && quota.Min > quota.Max {
+			return fmt.Errorf("quota for %s has min greater than max", category)
+		}
+	}
+	return nil
+}
+
+// PortfolioCosts membaca biaya dari alternatif yang sudah melalui
+// prepareRequest, sehingga biaya bersatuan sudah dalam satuan kriteria.
+func PortfolioCosts(alternatives []Alternative, attribute string) (map[string]float64, error) {
+	costs := make(map[string]float64, len(alternatives))
+	for _, alt := range alternatives {
+		cost, ok := alt.Values[attribute]
+		if !ok {
+			return nil, fmt.Errorf("Alternative %s is missing cost %s", alt.Name, attribute)
+		}
+		if cost < 0 {
+			return nil, fmt.Errorf("Alternative %s has a negative cost", alt.Name)
+		}
+		costs[alt.Name] = cost
+	}
+	return costs, nil
+}
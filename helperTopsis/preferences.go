@@ -0,0 +1,38 @@
+package helperTopsis
+
+// Preference menyatakan bahwa Better seharusnya berperingkat di atas Worse.
+type Preference struct {
+	Better string `json:"better"`
+	Worse  string `json:"worse"`
+}
+
+// PreferenceRequest meminta bobot kriteria yang memenuhi sebanyak mungkin
+// Preferences. Bobot pada Criteria menjadi titik awal pencarian.
+type PreferenceRequest struct {
+	TOPSISRequest
+	Preferences []Preference `json:"preferences"`
+	Samples     int          `json:"samples,omitempty"`
+	Seed        int64        `json:"seed,omitempty"`
+}
+
+// PreferenceOutcome adalah hasil satu preferensi dengan bobot hasil belajar.
+// Margin adalah closeness Better dikurangi closeness Worse.
+type PreferenceOutcome struct {
+	Better          string  `json:"better"`
+	Worse           string  `json:"worse"`
+	ClosenessBetter float64 `json:"closenessBetter"`
+	ClosenessWorse  float64 `json:"closenessWorse"`
+	Margin          float64 `json:"margin"`
+	Satisfied       bool    `json:"satisfied"`
+}
+
+type PreferenceResult struct {
+	Weights          map[string]float64  `json:"weights"`
+	Satisfied        int                 `json:"satisfied"`
+	Total            int                 `json:"total"`
+	Outcomes         []PreferenceOutcome `json:"outcomes"`
+	Unsatisfied      []Preference        `json:"unsatisfied,omitempty"`
+	SamplesEvaluated int                 `json:"samplesEvaluated"`
+	// kriteria dengan bobot hasil belajar, siap dipakai di TOPSISRequest
+	WeightedCriteria []Criterion `json:"weightedCriteria"`
+}
@@ -5,35 +5,156 @@ const (
 	Cost    = "cost"
 )
 
+// mode pra-proses dominasi Pareto pada TOPSISRequest.Dominance
+const (
+	DominanceMark    = "mark"
+	DominanceExclude = "exclude"
+)
+
 type Criterion struct {
 	Name   string  `json:"name"`
 	Weight float64 `json:"weight"`
 	Type   string  `json:"type"`
+	// batas penerimaan opsional, alternatif di luar batas langsung ditolak
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// skala linguistik opsional, memetakan label rubrik ke nilai numerik
+	Scale *LinguisticScale `json:"scale,omitempty"`
+	// kebijakan nilai kosong khusus kriteria ini, menimpa TOPSISRequest.MissingPolicy
+	MissingPolicy string `json:"missingPolicy,omitempty"`
+	// kriteria turunan: nilainya dihitung dari kolom lain, lihat Expression
+	Expression string `json:"expression,omitempty"`
+	// kolom bantu yang hanya dipakai oleh ekspresi, tidak ikut diranking dan
+	// tidak boleh punya bobot maupun Min/Max
+	Helper bool `json:"helper,omitempty"`
+	// satuan kanonik, nilai alternatif dengan satuan lain dikonversi ke sini
+	Unit string `json:"unit,omitempty"`
 }
 
 type Alternative struct {
 	Name   string             `json:"name"`
 	Values map[string]float64 `json:"values"`
+	// distribusi nilai opsional untuk analisis ketidakpastian
+	Distributions map[string]ValueDistribution `json:"distributions,omitempty"`
+	// label linguistik per kriteria, diubah ke angka lewat Criterion.Scale
+	Labels map[string]string `json:"labels,omitempty"`
+	// satuan nilai per kriteria jika berbeda dari Criterion.Unit
+	Units map[string]string `json:"units,omitempty"`
+	// nilai rentang [lower, upper] untuk TOPSIS interval
+	Intervals map[string]Interval `json:"intervals,omitempty"`
 }
 
 type TOPSISRequest struct {
 	Criteria     []Criterion   `json:"criteria"`
 	Alternatives []Alternative `json:"alternatives"`
+	Dominance    string        `json:"dominance,omitempty"`
+	// alternatif dari Criteria: pohon kriteria dengan bobot lokal per level
+	CriteriaTree []CriterionNode `json:"criteriaTree,omitempty"`
+	// kebijakan nilai kosong default, lihat konstanta Missing*
+	MissingPolicy string `json:"missingPolicy,omitempty"`
+	// metode normalisasi, lihat konstanta Normalization*
+	Normalization string `json:"normalization,omitempty"`
+	// metode jarak, lihat konstanta Distance*
+	Distance string `json:"distance,omitempty"`
+	// kovarians matriks ternormalisasi untuk jarak Mahalanobis, jika kosong
+	// diestimasi dari data
+	Covariance map[string]map[string]float64 `json:"covariance,omitempty"`
+	// pembangkit bobot dari urutan kepentingan, menimpa bobot di Criteria
+	Weighting *Weighting `json:"weighting,omitempty"`
+	// pengelompokan alternatif pada matriks ternormalisasi terbobot
+	Clustering *Clustering `json:"clustering,omitempty"`
 }
 
 type TOPSISResult struct {
-	Name             string             `json:"name"`
-	ClosenessValue   float64            `json:"closenessvalue"`
-	Rank             int                `json:"rank"`
-	PositiveDistance float64            `json:"positivedistance"`
-	NegativeDistance float64            `json:"negativedistance"`
-	NormalizedValues map[string]float64 `json:"normalizedvalues"`
-	WeightedValues   map[string]float64 `json:"WeightedValues"`
+	Name             string               `json:"name"`
+	ClosenessValue   float64              `json:"closenessvalue"`
+	Rank             int                  `json:"rank"`
+	PositiveDistance float64              `json:"positivedistance"`
+	NegativeDistance float64              `json:"negativedistance"`
+	NormalizedValues map[string]float64   `json:"normalizedvalues"`
+	WeightedValues   map[string]float64   `json:"WeightedValues"`
+	DominatedBy      []string             `json:"dominatedBy,omitempty"`
+	Labels           map[string]string    `json:"labels,omitempty"`
+	LabelValues      map[string]float64   `json:"labelValues,omitempty"`
+	OriginalValues   map[string]UnitValue `json:"originalValues,omitempty"`
+	ConvertedValues  map[string]UnitValue `json:"convertedValues,omitempty"`
+	// nomor kelompok jika TOPSISRequest.Clustering diisi, dimulai dari 1
+	Cluster int `json:"cluster,omitempty"`
 }
 
 type TOPSISResponse struct {
+	Results              []TOPSISResult        `json:"results"`
+	IdealPositive        map[string]float64    `json:"idealPositive"`
+	IdealNegative        map[string]float64    `json:"idealNegative"`
+	NormalizationFactors map[string]float64    `json:"normalizationFactors"`
+	Dominance            *DominanceReport      `json:"dominance,omitempty"`
+	Rejected             []RejectedAlternative `json:"rejected,omitempty"`
+	Branches             []BranchResult        `json:"branches,omitempty"`
+	Missing              *MissingValueReport   `json:"missing,omitempty"`
+	ExpressionErrors     []ExpressionError     `json:"expressionErrors,omitempty"`
+	Warnings             []CalculationWarning  `json:"warnings,omitempty"`
+	Weighting            *WeightingResult      `json:"weighting,omitempty"`
+	Clustering           *ClusteringResult     `json:"clustering,omitempty"`
+}
+
+// RejectedAlternative adalah alternatif yang gugur karena melanggar batas
+// min/max kriteria, beserta semua batas yang dilanggar.
+type RejectedAlternative struct {
+	Name       string                `json:"name"`
+	Violations []ConstraintViolation `json:"violations"`
+}
+
+type ConstraintViolation struct {
+	Criterion  string  `json:"criterion"`
+	Constraint string  `json:"constraint"`
+	Limit      float64 `json:"limit"`
+	Value      float64 `json:"value"`
+}
+
+// DominanceReport berisi himpunan efisien (Pareto) dan, untuk setiap
+// alternatif yang terdominasi, daftar alternatif yang mendominasinya.
+type DominanceReport struct {
+	ParetoSet []string            `json:"paretoSet"`
+	Dominated map[string][]string `json:"dominated"`
+	Excluded  []string            `json:"excluded,omitempty"`
+}
+
+// TOPSISStreamHeader adalah baris pertama pada input NDJSON mode streaming.
+// Jika Reference diisi, perhitungan cukup satu kali baca (single pass).
+type TOPSISStreamHeader struct {
+	Criteria  []Criterion      `json:"criteria"`
+	TopK      int              `json:"topK"`
+	Reference *StreamReference `json:"reference,omitempty"`
+}
+
+// StreamReference berisi faktor normalisasi dan solusi ideal tetap,
+// bentuknya sama dengan bagian yang dikembalikan TOPSISResponse sehingga
+// hasil perhitungan sebelumnya bisa dipakai ulang sebagai acuan.
+type StreamReference struct {
+	IdealPositive        map[string]float64 `json:"idealPositive"`
+	IdealNegative        map[string]float64 `json:"idealNegative"`
+	NormalizationFactors map[string]float64 `json:"normalizationFactors"`
+}
+
+// ClosenessSummary merangkum nilai closeness alternatif yang tidak masuk top-k.
+type ClosenessSummary struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+type TOPSISStreamResponse struct {
 	Results              []TOPSISResult     `json:"results"`
+	Rest                 ClosenessSummary   `json:"rest"`
+	TotalAlternatives    int                `json:"totalAlternatives"`
+	Passes               int                `json:"passes"`
 	IdealPositive        map[string]float64 `json:"idealPositive"`
 	IdealNegative        map[string]float64 `json:"idealNegative"`
 	NormalizationFactors map[string]float64 `json:"normalizationFactors"`
+	// jumlah baris yang ditolak batas Min/Max kriteria
+	Rejected         int                  `json:"rejected,omitempty"`
+	Warnings         []CalculationWarning `json:"warnings,omitempty"`
+	ExpressionErrors []ExpressionError    `json:"expressionErrors,omitempty"`
 }
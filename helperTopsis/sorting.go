@@ -0,0 +1,107 @@
+package helperTopsis
+
+import "fmt"
+
+// mode TOPSIS-Sort pada SortingRequest.Mode
+const (
+	// profil batas antar kelas (TOPSIS-Sort-B)
+	SortingBoundary = "boundary"
+	// profil karakteristik, satu per kelas (TOPSIS-Sort-C)
+	SortingCharacteristic = "characteristic"
+)
+
+// SortClass adalah satu kategori hasil sorting. Profile hanya dipakai pada
+// mode characteristic dan berisi nilai tipikal anggota kelas per kriteria.
+type SortClass struct {
+	Name    string             `json:"name"`
+	Profile map[string]float64 `json:"profile,omitempty"`
+}
+
+// SortingRequest mengelompokkan alternatif ke Classes yang diurutkan dari
+// kelas terbaik. Pada mode boundary, Boundaries[i] adalah batas bawah
+// Classes[i] sekaligus batas atas Classes[i+1], sehingga jumlahnya satu
+// kurang dari jumlah kelas. Nilai profil memakai satuan kanonik kriteria.
+type SortingRequest struct {
+	TOPSISRequest
+	Mode       string               `json:"mode"`
+	Classes    []SortClass          `json:"classes"`
+	Boundaries []map[string]float64 `json:"boundaries,omitempty"`
+}
+
+// SortProfile adalah closeness satu profil batas atau karakteristik.
+type SortProfile struct {
+	Name      string  `json:"name"`
+	Closeness float64 `json:"closeness"`
+}
+
+// SortAssignment adalah kelas satu alternatif. Margin adalah selisih
+// closeness ke batas kelas terdekat dan NearestClass kelas di balik batas
+// tersebut.
+type SortAssignment struct {
+	Name         string  `json:"name"`
+	Class        string  `json:"class"`
+	Closeness    float64 `json:"closeness"`
+	Margin       float64 `json:"margin"`
+	NearestClass string  `json:"nearestClass"`
+}
+
+type SortClassSummary struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type SortingResponse struct {
+	Mode             string                `json:"mode"`
+	Assignments      []SortAssignment      `json:"assignments"`
+	Classes          []SortClassSummary    `json:"classes"`
+	Profiles         []SortProfile         `json:"profiles"`
+	Rejected         []RejectedAlternative `json:"rejected,omitempty"`
+	Missing          *MissingValueReport   `json:"missing,omitempty"`
+	ExpressionErrors []ExpressionError     `json:"expressionErrors,omitempty"`
+	Warnings         []CalculationWarning  `json:"warnings,omitempty"`
+}
+
+func ValidateSortingRequest(req SortingRequest) error {
+	if req.Mode != SortingBoundary && req.Mode != SortingCharacteristic {
+		return fmt.Errorf("Invalid sorting mode : %s", req.Mode)
+	}
+	if len(req.Classes) < 2 {
+		return fmt.Errorf("sorting needs at least 2 classes")
+	}
+	names := make(map[string]bool, len(req.Classes))
+	for _, class := range req.Classes {
+		if class.Name == "" {
+			return fmt.Errorf("class name must not be empty")
+		}
+		if names[class.Name] {
+			return fmt.Errorf("class %s is listed twice", class.Name)
+		}
+		names[class.Name] = true
+		if req.Mode == SortingCharacteristic && class.Profile == nil {
+			return fmt.Errorf("class %s has no characteristic profile", class.Name)
+		}
+	}
+	if req.Mode == SortingBoundary && len(req.Boundaries) != len(req.Classes)-1 {
+		return fmt.Errorf("boundary mode needs %d boundaries, got %d", len(req.Classes)-1, len(req.Boundaries))
+	}
+	return nil
+}
+
+// SortingProfiles mengembalikan profil sebagai alternatif dengan nama yang
+// diawali "profile:" supaya bisa dihitung bersama alternatif sungguhan.
+func SortingProfiles(req SortingRequest) []Alternative {
+	var profiles []Alternative
+	if req.Mode == SortingBoundary {
+		for i, boundary := range req.Boundaries {
+			profiles = append(profiles, Alternative{
+				Name:   fmt.Sprintf("profile:%s/%s", req.Classes[i].Name, req.Classes[i+1].Name),
+				Values: boundary,
+			})
+		}
+		return profiles
+	}
+	for _, class := range req.Classes {
+		profiles = append(profiles, Alternative{Name: "profile:" + class.Name, Values: class.Profile})
+	}
+	return profiles
+}
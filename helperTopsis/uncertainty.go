@@ -0,0 +1,108 @@
+package helperTopsis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	DistributionNormal     = "normal"
+	DistributionUniform    = "uniform"
+	DistributionTriangular = "triangular"
+)
+
+// ValueDistribution menggambarkan ketidakpastian satu nilai alternatif.
+// normal memakai Mean dan StdDev, uniform memakai Min dan Max, triangular
+// memakai Min, Mode dan Max.
+type ValueDistribution struct {
+	Distribution string  `json:"distribution"`
+	Mean         float64 `json:"mean,omitempty"`
+	StdDev       float64 `json:"sd,omitempty"`
+	Min          float64 `json:"min,omitempty"`
+	Max          float64 `json:"max,omitempty"`
+	Mode         float64 `json:"mode,omitempty"`
+}
+
+func (d ValueDistribution) Validate() error {
+	switch d.Distribution {
+	case DistributionNormal:
+		if d.StdDev < 0 {
+			return fmt.Errorf("normal distribution has negative sd")
+		}
+	case DistributionUniform:
+		if d.Min > d.Max {
+			return fmt.Errorf("uniform distribution has min greater than max")
+		}
+	case DistributionTriangular:
+		if d.Min > d.Mode || d.Mode > d.Max {
+			return fmt.Errorf("triangular distribution requires min <= mode <= max")
+		}
+	default:
+		return fmt.Errorf("Invalid distribution : %s", d.Distribution)
+	}
+	return nil
+}
+
+// ExpectedValue adalah nilai titik yang dipakai perhitungan TOPSIS biasa.
+func (d ValueDistribution) ExpectedValue() float64 {
+	switch d.Distribution {
+	case DistributionUniform:
+		return (d.Min + d.Max) / 2
+	case DistributionTriangular:
+		return (d.Min + d.Mode + d.Max) / 3
+	default:
+		return d.Mean
+	}
+}
+
+func (d ValueDistribution) Sample(rng *rand.Rand) float64 {
+	switch d.Distribution {
+	case DistributionUniform:
+		return d.Min + rng.Float64()*(d.Max-d.Min)
+	case DistributionTriangular:
+		// inverse CDF distribusi segitiga
+		width := d.Max - d.Min
+		if width == 0 {
+			return d.Mode
+		}
+		u := rng.Float64()
+		split := (d.Mode - d.Min) / width
+		if u < split {
+			return d.Min + math.Sqrt(u*width*(d.Mode-d.Min))
+		}
+		return d.Max - math.Sqrt((1-u)*width*(d.Max-d.Mode))
+	default:
+		return d.Mean + rng.NormFloat64()*d.StdDev
+	}
+}
+
+// UncertaintyRequest menjalankan simulasi Monte Carlo atas TOPSISRequest,
+// mengambil sampel setiap nilai yang punya distribusi.
+type UncertaintyRequest struct {
+	TOPSISRequest
+	Iterations int     `json:"iterations,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+	Seed       int64   `json:"seed,omitempty"`
+}
+
+// AlternativeUncertainty berisi interval kepercayaan closeness dan peluang
+// setiap peringkat (indeks 0 adalah peringkat 1).
+type AlternativeUncertainty struct {
+	Name                string    `json:"name"`
+	ClosenessValue      float64   `json:"closenessvalue"`
+	MeanCloseness       float64   `json:"meanCloseness"`
+	ClosenessLower      float64   `json:"closenessLower"`
+	ClosenessUpper      float64   `json:"closenessUpper"`
+	RankProbabilities   []float64 `json:"rankProbabilities"`
+	RejectedProbability float64   `json:"rejectedProbability,omitempty"`
+}
+
+// UncertaintyResult.BeatsProbability[a][b] adalah peluang a mendapat
+// closeness lebih tinggi daripada b.
+type UncertaintyResult struct {
+	Iterations       int                           `json:"iterations"`
+	Confidence       float64                       `json:"confidence"`
+	Alternatives     []AlternativeUncertainty      `json:"alternatives"`
+	BeatsProbability map[string]map[string]float64 `json:"beatsProbability"`
+}
@@ -0,0 +1,179 @@
+package helperTopsis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// UnitDefinition menyatakan satu satuan sebagai kelipatan satuan dasar
+// dimensinya, misalnya cm = 0.01 m atau USD = 16000 IDR.
+type UnitDefinition struct {
+	Dimension string  `json:"dimension"`
+	Factor    float64 `json:"factor"`
+}
+
+// UnitRegistry adalah tabel konversi statis. Kurs mata uang tidak diambil
+// dari layanan luar, melainkan dari file konfigurasi.
+type UnitRegistry struct {
+	Units map[string]UnitDefinition `json:"units"`
+}
+
+// UnitValue adalah nilai beserta satuannya.
+type UnitValue struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// UnitConversion mencatat satu nilai alternatif yang dikonversi ke satuan
+// kanonik kriterianya.
+type UnitConversion struct {
+	Alternative string    `json:"alternative"`
+	Criterion   string    `json:"criterion"`
+	Original    UnitValue `json:"original"`
+	Converted   UnitValue `json:"converted"`
+}
+
+// DefaultUnitRegistry berisi satuan panjang, massa, dan waktu. Mata uang
+// tidak punya kurs bawaan dan harus dimuat dari konfigurasi.
+func DefaultUnitRegistry() UnitRegistry {
+	return UnitRegistry{Units: map[string]UnitDefinition{
+		"mm":   {Dimension: "length", Factor: 0.001},
+		"cm":   {Dimension: "length", Factor: 0.01},
+		"m":    {Dimension: "length", Factor: 1},
+		"km":   {Dimension: "length", Factor: 1000},
+		"g":    {Dimension: "mass", Factor: 0.001},
+		"kg":   {Dimension: "mass", Factor: 1},
+		"ton":  {Dimension: "mass", Factor: 1000},
+		"s":    {Dimension: "time", Factor: 1},
+		"min":  {Dimension: "time", Factor: 60},
+		"h":    {Dimension: "time", Factor: 3600},
+		"day":  {Dimension: "time", Factor: 86400},
+		"week": {Dimension: "time", Factor: 604800},
+	}}
+}
+
+// LoadUnitRegistry membaca tabel satuan dari file JSON dan menggabungkannya
+// dengan DefaultUnitRegistry, satuan dari file menimpa satuan bawaan.
+func LoadUnitRegistry(path string) (UnitRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return UnitRegistry{}, fmt.Errorf("failed to read unit config: %w", err)
+	}
+	var loaded UnitRegistry
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return UnitRegistry{}, fmt.Errorf("invalid unit config: %w", err)
+	}
+	registry := DefaultUnitRegistry()
+	for name, unit := range loaded.Units {
+		if unit.Dimension == "" || unit.Factor <= 0 {
+			return UnitRegistry{}, fmt.Errorf("unit %s needs a dimension and a positive factor", name)
+		}
+		registry.Units[name] = unit
+	}
+	return registry, nil
+}
+
+// Convert mengubah nilai dari satu satuan ke satuan lain dengan dimensi sama.
+func (r UnitRegistry) Convert(value float64, from, to string) (float64, error) {
+	source, ok := r.Units[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %s", from)
+	}
+	target, ok := r.Units[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %s", to)
+	}
+	if source.Dimension != target.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, source.Dimension, to, target.Dimension)
+	}
+	return value * source.Factor / target.Factor, nil
+}
+
+// ConvertUnits mengubah setiap nilai yang membawa satuan sendiri ke satuan
+// kanonik kriterianya. Nilai tanpa satuan dianggap sudah kanonik. Setelah
+// konversi Units dikosongkan supaya nilai tidak dikonversi dua kali.
+func ConvertUnits(req TOPSISRequest, registry UnitRegistry) (TOPSISRequest, []UnitConversion, error) {
+	canonical := make(map[string]string, len(req.Criteria))
+	for _, criterion := range req.Criteria {
+		if criterion.Unit == "" {
+			continue
+		}
+		if _, ok := registry.Units[criterion.Unit]; !ok {
+			return req, nil, fmt.Errorf("criteria %s has unknown unit %s", criterion.Name, criterion.Unit)
+		}
+		canonical[criterion.Name] = criterion.Unit
+	}
+
+	var conversions []UnitConversion
+	alternatives := make([]Alternative, len(req.Alternatives))
+	for i, alt := range req.Alternatives {
+		alternatives[i] = alt
+		if len(alt.Units) == 0 {
+			continue
+		}
+		values := make(map[string]float64, len(alt.Values))
+		for name, value := range alt.Values {
+			values[name] = value
+		}
+		for name, unit := range alt.Units {
+			target, ok := canonical[name]
+			if !ok {
+				return req, nil, fmt.Errorf(
+					"Alternative %s gives unit %s for criteria %s which has no unit", alt.Name, unit, name)
+			}
+			value, ok := values[name]
+			if !ok {
+				continue
+			}
+			converted, err := registry.Convert(value, unit, target)
+			if err != nil {
+				return req, nil, fmt.Errorf("Alternative %s, criteria %s: %w", alt.Name, name, err)
+			}
+			values[name] = converted
+			if interval, ok := alt.Intervals[name]; ok {
+				intervals := make(map[string]Interval, len(alt.Intervals))
+				for key, existing := range alternatives[i].Intervals {
+					intervals[key] = existing
+				}
+				lower, _ := registry.Convert(interval.Lower, unit, target)
+				upper, _ := registry.Convert(interval.Upper, unit, target)
+				intervals[name] = Interval{Lower: lower, Upper: upper}
+				alternatives[i].Intervals = intervals
+			}
+			conversions = append(conversions, UnitConversion{
+				Alternative: alt.Name,
+				Criterion:   name,
+				Original:    UnitValue{Value: value, Unit: unit},
+				Converted:   UnitValue{Value: converted, Unit: target},
+			})
+		}
+		alternatives[i].Values = values
+		alternatives[i].Units = nil
+	}
+	req.Alternatives = alternatives
+	return req, conversions, nil
+}
+
+// UnitResults menyalin nilai asli dan nilai hasil konversi ke hasil TOPSIS.
+func UnitResults(results []TOPSISResult, conversions []UnitConversion) {
+	if len(conversions) == 0 {
+		return
+	}
+	index := make(map[string]int, len(results))
+	for i, result := range results {
+		index[result.Name] = i
+	}
+	for _, conversion := range conversions {
+		i, ok := index[conversion.Alternative]
+		if !ok {
+			continue
+		}
+		if results[i].OriginalValues == nil {
+			results[i].OriginalValues = make(map[string]UnitValue)
+			results[i].ConvertedValues = make(map[string]UnitValue)
+		}
+		results[i].OriginalValues[conversion.Criterion] = conversion.Original
+		results[i].ConvertedValues[conversion.Criterion] = conversion.Converted
+	}
+}
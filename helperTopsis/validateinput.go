@@ -12,36 +12,128 @@ func ValidateInput(req TOPSISRequest) error {
 	if len(req.Alternatives) == 0 {
 		return fmt.Errorf("No Alternative Provided")
 	}
+	if err := ValidateCriteria(req.Criteria); err != nil {
+		return err
+	}
+	if err := validateExpressionVariables(req.Criteria, req.Alternatives); err != nil {
+		return err
+	}
+	if req.Dominance != "" && req.Dominance != DominanceMark && req.Dominance != DominanceExclude {
+		return fmt.Errorf("Invalid dominance mode : %s", req.Dominance)
+	}
+	if !isNormalization(req.Normalization) {
+		return fmt.Errorf("Invalid normalization : %s", req.Normalization)
+	}
+	if !isDistance(req.Distance) {
+		return fmt.Errorf("Invalid distance : %s", req.Distance)
+	}
+	if req.Covariance != nil {
+		if req.Distance != DistanceMahalanobis {
+			return fmt.Errorf("covariance is only used with mahalanobis distance")
+		}
+		if err := validateCovariance(req.Covariance, RankedCriteria(req.Criteria)); err != nil {
+			return err
+		}
+	}
+	if err := ValidateClustering(req.Clustering); err != nil {
+		return err
+	}
+	if !isMissingPolicy(req.MissingPolicy) {
+		return fmt.Errorf("Invalid missing value policy : %s", req.MissingPolicy)
+	}
+	criteria := withMissingPolicy(req.Criteria, req.MissingPolicy)
+	for _, alt := range req.Alternatives {
+		if err := ValidateAlternative(alt, criteria); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateCriteria memeriksa bobot dan tipe kriteria tanpa melihat alternatif,
+// dipakai juga oleh mode streaming yang membaca alternatif satu per satu.
+func ValidateCriteria(criteria []Criterion) error {
+	if len(criteria) == 0 {
+		return fmt.Errorf("No criteria Provided")
+	}
 	// check if all weight sum = 1.0
 	var weightSum float64
-	for _, criterion := range req.Criteria {
+	for _, criterion := range criteria {
 		if criterion.Weight < 0 {
 			return fmt.Errorf("criterion %s has negative weight", criterion.Name)
 		}
+		if criterion.Helper && criterion.Weight != 0 {
+			return fmt.Errorf("helper criterion %s must not have a weight", criterion.Name)
+		}
+		// batas Min/Max diperiksa setelah kolom bantu dibuang, jadi tidak berlaku
+		if criterion.Helper && (criterion.Min != nil || criterion.Max != nil) {
+			return fmt.Errorf("helper criterion %s must not have min or max", criterion.Name)
+		}
 		weightSum += criterion.Weight
 	}
 	// validasi agar jumlah weight nya tetap 1 dan mentoleransi ketika kurang dari 0.0001 , contohnya 0.00001
 	if math.Abs(weightSum-1.0) > 0.0001 {
 		return fmt.Errorf("weights do not sum to 1.0 (sum: %f)", weightSum)
 	}
-	criteriaNames := make(map[string]bool)
-	for _, criterion := range req.Criteria {
-		criteriaNames[criterion.Name] = true
-		if criterion.Type != Cost && criterion.Type != Benefit {
-			return fmt.Errorf("Invalid Criterion Type for %s : %s", criterion.Name, criterion.Type)
+	derived := make(map[string]bool)
+	for _, criterion := range criteria {
+		if criterion.Expression != "" {
+			derived[criterion.Name] = true
 		}
 	}
-	for _, alt := range req.Alternatives {
-		for criterionName := range criteriaNames {
-			//  fitur khusus di Go, yaitu multi-value return dari map look value, exists := map[key] , exists berisi boolean
-			if _, exists := alt.Values[criterionName]; !exists {
-				return fmt.Errorf(
-					"Alternative %s is missing Value for criteria %s",
-					alt.Name,
-					criterionName,
-				)
+	declared := make(map[string]bool, len(criteria))
+	for _, criterion := range criteria {
+		if criterion.Expression != "" {
+			if err := validateExpression(criterion, declared, derived); err != nil {
+				return err
 			}
 		}
+		declared[criterion.Name] = true
+		if !criterion.Helper && criterion.Type != Cost && criterion.Type != Benefit {
+			return fmt.Errorf("Invalid Criterion Type for %s : %s", criterion.Name, criterion.Type)
+		}
+		if criterion.Min != nil && criterion.Max != nil && *criterion.Min > *criterion.Max {
+			return fmt.Errorf("criterion %s has min greater than max", criterion.Name)
+		}
+		if !isMissingPolicy(criterion.MissingPolicy) {
+			return fmt.Errorf("Invalid missing value policy for %s : %s", criterion.Name, criterion.MissingPolicy)
+		}
+		if criterion.Scale != nil && len(criterion.Scale.Labels) == 0 {
+			return fmt.Errorf("criterion %s has an empty scale", criterion.Name)
+		}
+	}
+	return nil
+}
+
+func ValidateAlternative(alt Alternative, criteria []Criterion) error {
+	for _, criterion := range criteria {
+		// nilai kriteria turunan dihitung belakangan oleh DeriveCriteria
+		if criterion.Expression != "" {
+			continue
+		}
+		//  fitur khusus di Go, yaitu multi-value return dari map look value, exists := map[key] , exists berisi boolean
+		_, hasLabel := alt.Labels[criterion.Name]
+		allowMissing := criterion.MissingPolicy != "" && criterion.MissingPolicy != MissingReject
+		if _, exists := alt.Values[criterion.Name]; !exists && !hasLabel && !allowMissing {
+			return fmt.Errorf(
+				"Alternative %s is missing Value for criteria %s",
+				alt.Name,
+				criterion.Name,
+			)
+		}
+	}
+	if err := validateLabels(alt, criteria); err != nil {
+		return err
+	}
+	for criterionName, interval := range alt.Intervals {
+		if err := interval.Validate(); err != nil {
+			return fmt.Errorf("Alternative %s, criteria %s: %w", alt.Name, criterionName, err)
+		}
+	}
+	for criterionName, distribution := range alt.Distributions {
+		if err := distribution.Validate(); err != nil {
+			return fmt.Errorf("Alternative %s, criteria %s: %w", alt.Name, criterionName, err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,123 @@
+package helperTopsis
+
+import "fmt"
+
+// metode pembobotan pada Weighting.Method
+const (
+	WeightingRankSum        = "rank-sum"
+	WeightingRankReciprocal = "rank-reciprocal"
+	WeightingROC            = "roc"
+	WeightingSwing          = "swing"
+)
+
+// Weighting membangkitkan bobot kriteria dari urutan kepentingan. Order
+// berisi nama kriteria dari yang paling penting, dipakai oleh rank-sum,
+// rank-reciprocal dan roc. Metode swing (SMART) memakai Points, misalnya 100
+// untuk kriteria terpenting dan nilai lebih kecil untuk yang lain.
+type Weighting struct {
+	Method string             `json:"method"`
+	Order  []string           `json:"order,omitempty"`
+	Points map[string]float64 `json:"points,omitempty"`
+}
+
+// WeightingResult menampilkan bobot yang dipakai ranking.
+type WeightingResult struct {
+	Method  string             `json:"method"`
+	Weights map[string]float64 `json:"weights"`
+}
+
+// RankWeights menghitung bobot untuk peringkat 1..n (indeks 0 paling
+// penting):
+//
+//	rank-sum:        (n - r + 1) / Σ(n - k + 1)
+//	rank-reciprocal: (1 / r) / Σ(1 / k)
+//	roc:             (1 / n) · Σ_{k=r..n} 1 / k
+func RankWeights(method string, n int) ([]float64, error) {
+	weights := make([]float64, n)
+	sum := 0.0
+	for r := 1; r <= n; r++ {
+		switch method {
+		case WeightingRankSum:
+			weights[r-1] = float64(n - r + 1)
+		case WeightingRankReciprocal:
+			weights[r-1] = 1 / float64(r)
+		case WeightingROC:
+			for k := r; k <= n; k++ {
+				weights[r-1] += 1 / float64(k)
+			}
+		default:
+			return nil, fmt.Errorf("Invalid weighting method : %s", method)
+		}
+		sum += weights[r-1]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights, nil
+}
+
+// ApplyWeighting mengganti bobot kriteria yang diranking (bukan Helper)
+// dengan bobot dari req.Weighting. Bobot yang tertulis di Criteria diabaikan.
+func ApplyWeighting(req TOPSISRequest) (TOPSISRequest, *WeightingResult, error) {
+	if req.Weighting == nil {
+		return req, nil, nil
+	}
+	scheme := req.Weighting
+	ranked := make(map[string]bool)
+	for _, criterion := range RankedCriteria(req.Criteria) {
+		ranked[criterion.Name] = true
+	}
+	result := &WeightingResult{Method: scheme.Method, Weights: make(map[string]float64, len(ranked))}
+
+	if scheme.Method == WeightingSwing {
+		sum := 0.0
+		for name := range ranked {
+			points, ok := scheme.Points[name]
+			if !ok {
+				return req, nil, fmt.Errorf("swing points missing for criteria %s", name)
+			}
+			if points < 0 {
+				return req, nil, fmt.Errorf("swing points for %s are negative", name)
+			}
+			sum += points
+		}
+		for name := range scheme.Points {
+			if !ranked[name] {
+				return req, nil, fmt.Errorf("swing points given for unknown criteria %s", name)
+			}
+		}
+		if sum == 0 {
+			return req, nil, fmt.Errorf("swing points sum to zero")
+		}
+		for name := range ranked {
+			result.Weights[name] = scheme.Points[name] / sum
+		}
+	} else {
+		weights, err := RankWeights(scheme.Method, len(scheme.Order))
+		if err != nil {
+			return req, nil, err
+		}
+		if len(scheme.Order) != len(ranked) {
+			return req, nil, fmt.Errorf("weighting order has %d criteria, expected %d", len(scheme.Order), len(ranked))
+		}
+		for i, name := range scheme.Order {
+			if !ranked[name] {
+				return req, nil, fmt.Errorf("weighting order has unknown criteria %s", name)
+			}
+			if _, seen := result.Weights[name]; seen {
+				return req, nil, fmt.Errorf("weighting order lists %s twice", name)
+			}
+			result.Weights[name] = weights[i]
+		}
+	}
+
+	criteria := make([]Criterion, len(req.Criteria))
+	copy(criteria, req.Criteria)
+	for i := range criteria {
+		if weight, ok := result.Weights[criteria[i].Name]; ok {
+			criteria[i].Weight = weight
+		}
+	}
+	req.Criteria = criteria
+	return req, result, nil
+}
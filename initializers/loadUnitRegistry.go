@@ -0,0 +1,35 @@
+package initializers
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+
+	topsis "github.com/nabilulilalbab/TopsisByme/TOPSIS"
+	"github.com/nabilulilalbab/TopsisByme/helperTopsis"
+)
+
+// DefaultUnitConfigFile adalah lokasi tabel satuan jika UNIT_CONFIG_FILE
+// tidak diisi.
+const DefaultUnitConfigFile = "config/units.json"
+
+// LoadUnitRegistry memuat tabel konversi satuan dan kurs dari
+// config/units.json, atau dari file yang ditunjuk UNIT_CONFIG_FILE. Jika
+// file bawaan tidak ada, hanya satuan bawaan yang tersedia dan peringatan
+// ditulis ke log karena konversi mata uang akan gagal.
+func LoadUnitRegistry() {
+	path := os.Getenv("UNIT_CONFIG_FILE")
+	if path == "" {
+		path = DefaultUnitConfigFile
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			log.Printf("warning: unit config %s not found, only built-in units are available", path)
+			return
+		}
+	}
+	registry, err := helperTopsis.LoadUnitRegistry(path)
+	if err != nil {
+		log.Fatalf("error Load unit config: %v", err)
+	}
+	topsis.SetUnitRegistry(registry)
+}
@@ -14,6 +14,7 @@ import (
 	usercontroller "github.com/nabilulilalbab/TopsisByme/controllers/userController"
 	"github.com/nabilulilalbab/TopsisByme/database"
 	_ "github.com/nabilulilalbab/TopsisByme/docs" // Import docs
+	"github.com/nabilulilalbab/TopsisByme/initializers"
 	"github.com/nabilulilalbab/TopsisByme/middleware"
 )
 
@@ -43,6 +44,9 @@ func init() {
 	// Load environment variables
 	// initializers.LoadVariables()
 
+	// Load unit and currency conversion tables
+	initializers.LoadUnitRegistry()
+
 	// Set Gin to release mode
 	gin.SetMode(gin.ReleaseMode)
 
@@ -130,6 +134,20 @@ func setupRoutes(router *gin.Engine) {
 	topsisRoutes.Use(middleware.RequireAuth)
 	{
 		topsisRoutes.POST("/", topsiscontroller.HandleTopsis)
+		topsisRoutes.POST("/stream", topsiscontroller.HandleTopsisStream)
+		topsisRoutes.POST("/explain", topsiscontroller.HandleTopsisExplain)
+		topsisRoutes.POST("/improve", topsiscontroller.HandleTopsisImprove)
+		topsisRoutes.POST("/inverse-weights", topsiscontroller.HandleTopsisInverseWeights)
+		topsisRoutes.POST("/uncertainty", topsiscontroller.HandleTopsisUncertainty)
+		topsisRoutes.POST("/dynamic", topsiscontroller.HandleTopsisDynamic)
+		topsisRoutes.POST("/intuitionistic", topsiscontroller.HandleTopsisIntuitionistic)
+		topsisRoutes.POST("/interval", topsiscontroller.HandleTopsisInterval)
+		topsisRoutes.POST("/dematel", topsiscontroller.HandleDematel)
+		topsisRoutes.POST("/bwm", topsiscontroller.HandleBestWorst)
+		topsisRoutes.POST("/learn-weights", topsiscontroller.HandleLearnWeights)
+		topsisRoutes.POST("/sort", topsiscontroller.HandleTopsisSort)
+		topsisRoutes.POST("/portfolio", topsiscontroller.HandleTopsisPortfolio)
+		topsisRoutes.POST("/generate", topsiscontroller.HandleTopsisGenerate)
 		topsisRoutes.POST("/save", topsiscontroller.SaveTopsisResult)
 		topsisRoutes.GET("/history", topsiscontroller.GetAllTopsisHistory)
 		topsisRoutes.GET("/:id", topsiscontroller.TopsisGetById)